@@ -0,0 +1,262 @@
+package Layouts
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrTagMissingColumnsValue error = errors.New("expected value for \"columns\" tag entry")
+var ErrTagInvalidColumnsValue error = errors.New("invalid columns list on \"columns\" tag entry")
+
+/**
+ * Columns of a multi-column field, a single column has the same first and
+ * last column number
+ */
+type columnSpan struct {
+	First int
+	Last  int
+}
+
+/**
+ * Parse a columns list like "H-M", "H,J,L" or "H|J|L-M"
+ */
+func parseColumnSpans(val string) ([]columnSpan, error) {
+	spans := []columnSpan{}
+	for _, part := range strings.FieldsFunc(val, func(r rune) bool { return r == ',' || r == '|' }) {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		first, err := excelize.ColumnNameToNumber(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, ErrTagInvalidColumnsValue
+		}
+		span := columnSpan{First: first, Last: first}
+		if len(bounds) > 1 {
+			if span.Last, err = excelize.ColumnNameToNumber(strings.TrimSpace(bounds[1])); err != nil || span.Last < first {
+				return nil, ErrTagInvalidColumnsValue
+			}
+		}
+		spans = append(spans, span)
+	}
+	if len(spans) == 0 {
+		return nil, ErrTagMissingColumnsValue
+	}
+	return spans, nil
+}
+
+/**
+ * Column name or range written without a key, like the "J" and "L-M" items
+ * of an unquoted "columns:H,J,L-M" list
+ */
+var columnsItemRegex = regexp.MustCompile(`^\s*[A-Za-z]{1,3}(\s*-\s*[A-Za-z]{1,3})?\s*$`)
+
+/**
+ * Check if a tag option continues an unquoted columns list, the column names
+ * that are also option names like "url" are read as options
+ */
+func isColumnsItem(option string) bool {
+	if !columnsItemRegex.MatchString(option) {
+		return false
+	}
+	ft, err := parseOptions(`excelLayout:"` + option + `"`)
+	return err == nil && reflect.DeepEqual(ft, fieldTags{})
+}
+
+/**
+ * Return the columns list as written on the tag, like "H-M, P"
+ */
+func columnSpansText(spans []columnSpan) string {
+	parts := []string{}
+	for _, s := range spans {
+		first, _ := excelize.ColumnNumberToName(s.First)
+		if s.Last == s.First {
+			parts = append(parts, first)
+			continue
+		}
+		last, _ := excelize.ColumnNumberToName(s.Last)
+		parts = append(parts, first+"-"+last)
+	}
+	return strings.Join(parts, ", ")
+}
+
+/**
+ * Return the first column of every item, the ranges are split in blocks of
+ * the item width and every single column starts an item
+ */
+func columnStarts(spans []columnSpan, width int) []int {
+	starts := []int{}
+	for _, s := range spans {
+		for col := s.First; col+width-1 <= s.Last || col == s.First; col += width {
+			starts = append(starts, col)
+		}
+	}
+	return starts
+}
+
+/**
+ * Check if the slice items are read as a group of columns
+ */
+func isGroupType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct && !isValueType(t.Elem())
+}
+
+/**
+ * Return the number of columns of a repeating group, the greatest column of
+ * the group fields
+ */
+func groupWidth(fields []layoutField) int {
+	width := 1
+	for _, field := range fields {
+		if col, err := excelize.ColumnNameToNumber(field.Tags.Column); err == nil && col > width {
+			width = col
+		}
+	}
+	return width
+}
+
+/**
+ * Return the row cells of a multi-column field value, the items exceeding
+ * the field columns are not written
+ */
+func columnCells(f reflect.Value, tags fieldTags) []string {
+	cells := []string{}
+	setCell := func(col int, value string) {
+		for len(cells) < col {
+			cells = append(cells, "")
+		}
+		cells[col-1] = value
+	}
+
+	if !isGroupType(f.Type()) {
+		for i, col := range columnStarts(tags.Columns, 1) {
+			if i < f.Len() {
+				setCell(col, formatValue(f.Index(i), tags))
+			}
+		}
+		return cells
+	}
+
+	fields := layoutFields(f.Type().Elem())
+	for i, start := range columnStarts(tags.Columns, groupWidth(fields)) {
+		if i >= f.Len() {
+			break
+		}
+		for _, field := range fields {
+			if col, err := excelize.ColumnNameToNumber(field.Tags.Column); err == nil {
+				setCell(start+col-1, formatValue(f.Index(i).FieldByIndex(field.Index), field.Tags))
+			}
+		}
+	}
+	return cells
+}
+
+/**
+ * Return the cell value of a column number, empty when the row is shorter
+ */
+func cellValue(cells []string, col int) string {
+	if col < 1 || col > len(cells) {
+		return ""
+	}
+	return cells[col-1]
+}
+
+/**
+ * Parse the cells of a multi-column field into the slice, every column is an
+ * item for scalar slices and every group of columns an item for slices of
+ * structs. The empty cells and the groups with all their cells empty are
+ * skipped, the errors Item is the position of the column or group
+ */
+func (l *ExcelLayout) parseColumns(f reflect.Value, tags fieldTags, rowIndex int, cells []string) []Error {
+	firstColumn, _ := excelize.ColumnNumberToName(tags.Columns[0].First)
+	columnName := func(col int) string {
+		name, _ := excelize.ColumnNumberToName(col)
+		return name
+	}
+
+	if !isGroupType(f.Type()) {
+		starts := columnStarts(tags.Columns, 1)
+		values := []string{}
+		positions := []int{}
+		for i, col := range starts {
+			if value := cellValue(cells, col); strings.TrimSpace(value) != "" {
+				values = append(values, value)
+				positions = append(positions, i)
+			}
+		}
+		errors := []Error{}
+		if tags.Required && len(values) == 0 {
+			errors = append(errors, Error{RowIndex: rowIndex, Column: firstColumn, Error: ErrRequiredValueRuleFail})
+		}
+		for _, e := range parseItems(f, values, tags) {
+			if ie, ok := e.(*itemError); ok {
+				i := positions[ie.Item-1]
+				errors = append(errors, Error{RowIndex: rowIndex, Column: columnName(starts[i]), Error: ie.Err, Item: i + 1})
+			} else {
+				errors = append(errors, Error{RowIndex: rowIndex, Column: firstColumn, Error: e})
+			}
+		}
+		return errors
+	}
+
+	fields := layoutFields(f.Type().Elem())
+	errors := []Error{}
+	count := 0
+	seen := map[string]bool{}
+	for i, start := range columnStarts(tags.Columns, groupWidth(fields)) {
+		item := reflect.New(f.Type().Elem()).Elem()
+		empty := true
+		itemErrors := []Error{}
+		for _, field := range fields {
+			col, err := excelize.ColumnNameToNumber(field.Tags.Column)
+			if err != nil {
+				continue
+			}
+			col += start - 1
+			value := cellValue(cells, col)
+			empty = empty && strings.TrimSpace(value) == ""
+			cellTags := field.Tags
+			if cellTags.Locale == "" {
+				cellTags.Locale = l.locale
+			}
+			if l.rawValues {
+				cellTags.Locale = ""
+				cellTags.serialDates = true
+			}
+			for _, e := range parseValue(item.FieldByIndex(field.Index), value, cellTags) {
+				cellErr := newError(rowIndex, columnName(col), e)
+				cellErr.Item = i + 1
+				itemErrors = append(itemErrors, cellErr)
+			}
+		}
+		if empty {
+			continue
+		}
+		count++
+		if len(itemErrors) > 0 {
+			errors = append(errors, itemErrors...)
+			continue
+		}
+		if tags.UniqueItems {
+			key := fmt.Sprintf("%v", item.Interface())
+			if seen[key] {
+				errors = append(errors, Error{RowIndex: rowIndex, Column: columnName(start), Error: ErrUniqueItemsRuleFail, Item: i + 1})
+				continue
+			}
+			seen[key] = true
+		}
+		f.Set(reflect.Append(f, item))
+	}
+	if tags.Required && count == 0 {
+		errors = append(errors, Error{RowIndex: rowIndex, Column: firstColumn, Error: ErrRequiredValueRuleFail})
+	}
+	if tags.hasMinItems && count < int(tags.MinItems) {
+		errors = append(errors, Error{RowIndex: rowIndex, Column: firstColumn, Error: ErrMinItemsRuleFail})
+	}
+	if tags.hasMaxItems && count > int(tags.MaxItems) {
+		errors = append(errors, Error{RowIndex: rowIndex, Column: firstColumn, Error: ErrMaxItemsRuleFail})
+	}
+	return errors
+}
@@ -0,0 +1,189 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseColumnSpans(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected []columnSpan
+		err      error
+	}{
+		{"H-M", []columnSpan{{8, 13}}, nil},
+		{"H,J,L", []columnSpan{{8, 8}, {10, 10}, {12, 12}}, nil},
+		{"h|j-k", []columnSpan{{8, 8}, {10, 11}}, nil},
+		{"M-H", nil, ErrTagInvalidColumnsValue},
+		{"H-", nil, ErrTagInvalidColumnsValue},
+		{"1-2", nil, ErrTagInvalidColumnsValue},
+		{",", nil, ErrTagMissingColumnsValue},
+	}
+
+	for i, test := range tests {
+		result, err := parseColumnSpans(test.input)
+		if err != test.err || !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test %d: Expected %v (%v), Recived: %v (%v)", i, test.expected, test.err, result, err)
+		}
+	}
+}
+
+func TestColumnsTag(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected []columnSpan
+		required bool
+		url      bool
+	}{
+		{`excelLayout:"columns:H,J,L"`, []columnSpan{{8, 8}, {10, 10}, {12, 12}}, false, false},
+		{`excelLayout:"columns:'H,J',required"`, []columnSpan{{8, 8}, {10, 10}}, true, false},
+		{`excelLayout:"columns:H, J-K ,required"`, []columnSpan{{8, 8}, {10, 11}}, true, false},
+		{`excelLayout:"columns:H,J,url"`, []columnSpan{{8, 8}, {10, 10}}, false, true},
+	}
+
+	for i, test := range tests {
+		tags, err := parseOptions(test.input)
+		if err != nil || !reflect.DeepEqual(tags.Columns, test.expected) || tags.Required != test.required || tags.Url != test.url {
+			t.Errorf("Test %d: Expected %v, Recived: %v (%v)", i, test.expected, tags.Columns, err)
+		}
+	}
+}
+
+func TestColumnStarts(t *testing.T) {
+
+	tests := []struct {
+		spans    []columnSpan
+		width    int
+		expected []int
+	}{
+		{[]columnSpan{{8, 13}}, 1, []int{8, 9, 10, 11, 12, 13}},
+		{[]columnSpan{{8, 13}}, 2, []int{8, 10, 12}},
+		{[]columnSpan{{8, 13}}, 4, []int{8}},
+		{[]columnSpan{{8, 8}, {12, 12}}, 3, []int{8, 12}},
+	}
+
+	for i, test := range tests {
+		if result := columnStarts(test.spans, test.width); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.expected, result)
+		}
+	}
+}
+
+type testGroupContact struct {
+	Name  string `excelLayout:"column:A,required"`
+	Phone string `excelLayout:"column:B,pattern:phone_mx"`
+}
+
+type testMonthlyRow struct {
+	Row
+	ID       int64              `excelLayout:"column:A,required"`
+	Phones   []string           `excelLayout:"columns:'B,D',dropEmpty"`
+	Months   []float64          `excelLayout:"columns:E-G,min:0"`
+	Contacts []testGroupContact `excelLayout:"columns:H-M,maxItems:2"`
+}
+
+func TestColumnsCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testMonthlyRow{Row: Row{Index: 2}}
+	cells := []string{"1", "5512345678", "x", "", "1.5", "2", "3", "Ana", "5511111111", "", "", "Luis", "5522222222"}
+	if errs := l.ParseCells(&row, cells); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected := testMonthlyRow{
+		Row:      Row{Index: 2},
+		ID:       1,
+		Phones:   []string{"5512345678"},
+		Months:   []float64{1.5, 2, 3},
+		Contacts: []testGroupContact{{"Ana", "5511111111"}, {"Luis", "5522222222"}},
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, row)
+	}
+
+	cells = []string{"2", "", "", "", "1", "-2", "3", "Ana", "55", "Eva", "5511111111", "", "5522222222"}
+	errs := l.ParseCells(&testMonthlyRow{Row: Row{Index: 3}}, cells)
+	expectedErrs := []Error{
+		{RowIndex: 3, Column: "F", Error: ErrMinValueRuleFail, Item: 2},
+		{RowIndex: 3, Column: "I", Error: ErrPatternRuleFail, Item: 1},
+		{RowIndex: 3, Column: "L", Error: ErrRequiredValueRuleFail, Item: 3},
+		{RowIndex: 3, Column: "H", Error: ErrMaxItemsRuleFail},
+	}
+	if !reflect.DeepEqual(errs, expectedErrs) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expectedErrs, errs)
+	}
+
+	row = testMonthlyRow{Row: Row{Index: 4}}
+	cells = []string{"3", "", "", "", "", "x", "3"}
+	errs = l.ParseCells(&row, cells)
+	expectedErrs = []Error{{RowIndex: 4, Column: "F", Error: ErrDecimalInvalid, Item: 2}}
+	if !reflect.DeepEqual(errs, expectedErrs) || !reflect.DeepEqual(row.Months, []float64{3}) {
+		t.Errorf("Test 2: Expected %v, Recived: %v %v", expectedErrs, errs, row.Months)
+	}
+}
+
+func TestColumnsStructParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testMonthlyRow{
+		Row:      Row{Index: 4},
+		ID:       1,
+		Months:   []float64{1, 2, 3},
+		Contacts: []testGroupContact{{"Ana", "5511111111"}, {"", "5522222222"}},
+	}
+	errs := l.ParseStruct(&row)
+	expected := []Error{{RowIndex: 4, Column: "J", Error: ErrRequiredValueRuleFail, Item: 2}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+type testOffsetMonthlyRow struct {
+	Row
+	Sales struct {
+		Months []int64 `excelLayout:"columns:A-C"`
+	} `excelLayout:"offset:C"`
+}
+
+func TestColumnsFileRead(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"ID", "Nombre", "Ene", "Feb", "Mar"},
+		{1, "Norte", 10, 20, 30},
+		{2, "Sur", 5, 6, 7},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testOffsetMonthlyRow{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	rows := l.GetRows()
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, Recived: %d", len(rows))
+	}
+	if months := rows[1].(*testOffsetMonthlyRow).Sales.Months; !reflect.DeepEqual(months, []int64{5, 6, 7}) {
+		t.Errorf("Expected %v, Recived: %v", []int64{5, 6, 7}, months)
+	}
+}
+
+func TestColumnsSpecCells(t *testing.T) {
+	spec, err := LoadLayoutSpec([]byte(`{"columns": [
+		{"name": "Months", "type": "int", "columns": "B-D", "max": 100}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	l := ExcelLayout{}
+	row, errs := l.ParseSpecCells(spec, 2, []string{"x", "1", "2", "300"})
+	expected := []Error{{RowIndex: 2, Column: "D", Error: ErrMaxValueRuleFail, Item: 3}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+	if months := row["Months"]; !reflect.DeepEqual(months, []int64{1, 2}) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", []int64{1, 2}, months)
+	}
+}
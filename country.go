@@ -0,0 +1,262 @@
+package Layouts
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrTagUnknownCountry error = errors.New("unknown ISO 3166 country code on tag entry")
+var ErrTagMissingZipValue error = errors.New("expected value for \"zip\" tag entry")
+var ErrTagUnknownZipCountry error = errors.New("no postal code format for the \"zip\" tag entry country")
+var ErrTagInvalidCountryFormat error = errors.New("the \"country\" tag entry value should be \"alpha2\" or \"alpha3\"")
+var ErrPhoneRuleFail error = errors.New("invalid phone number value")
+var ErrZipRuleFail error = errors.New("invalid postal code value")
+var ErrCountryRuleFail error = errors.New("invalid country code value")
+var ErrCurrencyRuleFail error = errors.New("invalid currency code value")
+
+/**
+ * ISO 3166-1 countries as "alpha-2 alpha-3 calling-code" entries, the calling
+ * code is "-" for the territories without one
+ */
+const countryData = `AD AND 376|AE ARE 971|AF AFG 93|AG ATG 1|AI AIA 1|AL ALB 355|AM ARM 374|AO AGO 244|
+AQ ATA 672|AR ARG 54|AS ASM 1|AT AUT 43|AU AUS 61|AW ABW 297|AX ALA 358|AZ AZE 994|BA BIH 387|BB BRB 1|
+BD BGD 880|BE BEL 32|BF BFA 226|BG BGR 359|BH BHR 973|BI BDI 257|BJ BEN 229|BL BLM 590|BM BMU 1|BN BRN 673|
+BO BOL 591|BQ BES 599|BR BRA 55|BS BHS 1|BT BTN 975|BV BVT -|BW BWA 267|BY BLR 375|BZ BLZ 501|CA CAN 1|
+CC CCK 61|CD COD 243|CF CAF 236|CG COG 242|CH CHE 41|CI CIV 225|CK COK 682|CL CHL 56|CM CMR 237|CN CHN 86|
+CO COL 57|CR CRI 506|CU CUB 53|CV CPV 238|CW CUW 599|CX CXR 61|CY CYP 357|CZ CZE 420|DE DEU 49|DJ DJI 253|
+DK DNK 45|DM DMA 1|DO DOM 1|DZ DZA 213|EC ECU 593|EE EST 372|EG EGY 20|EH ESH 212|ER ERI 291|ES ESP 34|
+ET ETH 251|FI FIN 358|FJ FJI 679|FK FLK 500|FM FSM 691|FO FRO 298|FR FRA 33|GA GAB 241|GB GBR 44|GD GRD 1|
+GE GEO 995|GF GUF 594|GG GGY 44|GH GHA 233|GI GIB 350|GL GRL 299|GM GMB 220|GN GIN 224|GP GLP 590|GQ GNQ 240|
+GR GRC 30|GS SGS 500|GT GTM 502|GU GUM 1|GW GNB 245|GY GUY 592|HK HKG 852|HM HMD -|HN HND 504|HR HRV 385|
+HT HTI 509|HU HUN 36|ID IDN 62|IE IRL 353|IL ISR 972|IM IMN 44|IN IND 91|IO IOT 246|IQ IRQ 964|IR IRN 98|
+IS ISL 354|IT ITA 39|JE JEY 44|JM JAM 1|JO JOR 962|JP JPN 81|KE KEN 254|KG KGZ 996|KH KHM 855|KI KIR 686|
+KM COM 269|KN KNA 1|KP PRK 850|KR KOR 82|KW KWT 965|KY CYM 1|KZ KAZ 7|LA LAO 856|LB LBN 961|LC LCA 1|
+LI LIE 423|LK LKA 94|LR LBR 231|LS LSO 266|LT LTU 370|LU LUX 352|LV LVA 371|LY LBY 218|MA MAR 212|MC MCO 377|
+MD MDA 373|ME MNE 382|MF MAF 590|MG MDG 261|MH MHL 692|MK MKD 389|ML MLI 223|MM MMR 95|MN MNG 976|MO MAC 853|
+MP MNP 1|MQ MTQ 596|MR MRT 222|MS MSR 1|MT MLT 356|MU MUS 230|MV MDV 960|MW MWI 265|MX MEX 52|MY MYS 60|
+MZ MOZ 258|NA NAM 264|NC NCL 687|NE NER 227|NF NFK 672|NG NGA 234|NI NIC 505|NL NLD 31|NO NOR 47|NP NPL 977|
+NR NRU 674|NU NIU 683|NZ NZL 64|OM OMN 968|PA PAN 507|PE PER 51|PF PYF 689|PG PNG 675|PH PHL 63|PK PAK 92|
+PL POL 48|PM SPM 508|PN PCN 64|PR PRI 1|PS PSE 970|PT PRT 351|PW PLW 680|PY PRY 595|QA QAT 974|RE REU 262|
+RO ROU 40|RS SRB 381|RU RUS 7|RW RWA 250|SA SAU 966|SB SLB 677|SC SYC 248|SD SDN 249|SE SWE 46|SG SGP 65|
+SH SHN 290|SI SVN 386|SJ SJM 47|SK SVK 421|SL SLE 232|SM SMR 378|SN SEN 221|SO SOM 252|SR SUR 597|SS SSD 211|
+ST STP 239|SV SLV 503|SX SXM 1|SY SYR 963|SZ SWZ 268|TC TCA 1|TD TCD 235|TF ATF 262|TG TGO 228|TH THA 66|
+TJ TJK 992|TK TKL 690|TL TLS 670|TM TKM 993|TN TUN 216|TO TON 676|TR TUR 90|TT TTO 1|TV TUV 688|TW TWN 886|
+TZ TZA 255|UA UKR 380|UG UGA 256|UM UMI 1|US USA 1|UY URY 598|UZ UZB 998|VA VAT 39|VC VCT 1|VE VEN 58|
+VG VGB 1|VI VIR 1|VN VNM 84|VU VUT 678|WF WLF 681|WS WSM 685|YE YEM 967|YT MYT 262|ZA ZAF 27|ZM ZMB 260|
+ZW ZWE 263`
+
+/**
+ * Active ISO 4217 currency codes, including the funds and precious metals codes
+ */
+const currencyData = `AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV
+BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN
+ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES
+KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN
+MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG
+SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU
+UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XUA YER ZAR ZMW
+ZWG`
+
+type country struct {
+	Alpha2      string
+	Alpha3      string
+	CallingCode string
+}
+
+var countries = map[string]country{}
+var callingCodes = map[string]bool{}
+var currencies = map[string]bool{}
+
+func init() {
+	for _, entry := range strings.Split(countryData, "|") {
+		data := strings.Fields(entry)
+		c := country{Alpha2: data[0], Alpha3: data[1]}
+		if data[2] != "-" {
+			c.CallingCode = data[2]
+			callingCodes[c.CallingCode] = true
+		}
+		countries[c.Alpha2] = c
+		countries[c.Alpha3] = c
+	}
+	for _, code := range strings.Fields(currencyData) {
+		currencies[code] = true
+	}
+}
+
+/**
+ * Return the country of an alpha-2 or alpha-3 code, case insensitive
+ */
+func findCountry(code string) (country, bool) {
+	c, exists := countries[strings.ToUpper(strings.TrimSpace(code))]
+	return c, exists
+}
+
+/**
+ * Return the country code on the "alpha2" (default) or "alpha3" format
+ */
+func normalizeCountry(value string, format string) (string, bool) {
+	c, exists := findCountry(value)
+	if !exists {
+		return "", false
+	}
+	if format == "alpha3" {
+		return c.Alpha3, true
+	}
+	return c.Alpha2, true
+}
+
+/**
+ * Return the currency code in upper case
+ */
+func normalizeCurrency(value string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	return code, currencies[code]
+}
+
+/**
+ * Postal code format of a country, normalize builds the stored value
+ */
+type zipFormat struct {
+	Regex     *regexp.Regexp
+	Normalize func(string) string
+}
+
+var zipFormats = map[string]zipFormat{
+	"MX": {
+		Regex: regexp.MustCompile(fullMatchRegex(patterns["zip_mx"])),
+	},
+	"US": {
+		Regex: regexp.MustCompile(`^\d{5}(-?\d{4})?$`),
+		Normalize: func(v string) string {
+			if len(v) == 9 {
+				return v[:5] + "-" + v[5:]
+			}
+			return v
+		},
+	},
+	"CA": {
+		Regex: regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+		Normalize: func(v string) string {
+			if len(v) == 6 {
+				return v[:3] + " " + v[3:]
+			}
+			return v
+		},
+	},
+}
+
+/**
+ * Return the postal code in the format of the first country that matches
+ */
+func normalizeZip(value string, codes []string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	for _, c := range codes {
+		format := zipFormats[c]
+		if !format.Regex.MatchString(code) {
+			continue
+		}
+		if format.Normalize != nil {
+			code = format.Normalize(code)
+		}
+		return code, true
+	}
+	return "", false
+}
+
+/**
+ * Length of the national significant number by calling code, the other
+ * codes are checked with the E.164 limits only
+ */
+var phoneLengths = map[string]int{
+	"1":  10,
+	"52": 10,
+	"34": 9,
+	"33": 9,
+}
+
+/**
+ * Return the phone number on E.164 format ("+5215512345678" is returned as
+ * "+525512345678"), the numbers without international prefix ("+" or "00")
+ * use the calling code of the default country
+ */
+func normalizePhone(value string, defaultCountry string) (string, bool) {
+	v := strings.TrimSpace(value)
+	international := strings.HasPrefix(v, "+")
+	if international {
+		v = v[1:]
+	}
+
+	digits := strings.Builder{}
+	for _, r := range v {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case strings.ContainsRune(" -.()/", r):
+			continue
+		default:
+			return "", false
+		}
+	}
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		number, international = number[2:], true
+	}
+
+	code := ""
+	if international {
+		for i := 1; i <= 3 && i <= len(number); i++ {
+			if callingCodes[number[:i]] {
+				code = number[:i]
+				break
+			}
+		}
+		if code == "" {
+			return "", false
+		}
+		number = number[len(code):]
+	} else {
+		c, exists := findCountry(defaultCountry)
+		if !exists || c.CallingCode == "" {
+			return "", false
+		}
+		code = c.CallingCode
+		number = nationalNumber(code, number)
+	}
+
+	if code == "52" && len(number) == 11 && strings.HasPrefix(number, "1") {
+		number = number[1:]
+	}
+	if length, exists := phoneLengths[code]; exists && len(number) != length {
+		return "", false
+	}
+	if total := len(code) + len(number); total < 8 || total > 15 {
+		return "", false
+	}
+
+	return "+" + code + number, true
+}
+
+/**
+ * Remove the national trunk prefix of a phone number dialed inside the country
+ */
+func nationalNumber(code string, number string) string {
+	switch code {
+	case "1":
+		if len(number) == 11 && strings.HasPrefix(number, "1") {
+			return number[1:]
+		}
+	case "52":
+		if len(number) == 13 && (strings.HasPrefix(number, "044") || strings.HasPrefix(number, "045")) {
+			return number[3:]
+		}
+		if len(number) == 12 && strings.HasPrefix(number, "01") {
+			return number[2:]
+		}
+	case "39":
+		// the leading zero is part of the italian numbers
+	default:
+		return strings.TrimLeft(number, "0")
+	}
+	return number
+}
@@ -0,0 +1,148 @@
+package Layouts
+
+import (
+	"strings"
+	"testing"
+)
+
+type normalizeTests struct {
+	input    string
+	option   string
+	expected string
+	valid    bool
+}
+
+func TestNormalizePhone(t *testing.T) {
+
+	tests := []normalizeTests{
+		{"+52 55 1234 5678", "", "+525512345678", true},
+		{"+52 1 55 1234 5678", "", "+525512345678", true},
+		{"0052 (55) 1234-5678", "", "+525512345678", true},
+		{"55 1234 5678", "MX", "+525512345678", true},
+		{"044 55 1234 5678", "MX", "+525512345678", true},
+		{"(212) 555-0100", "US", "+12125550100", true},
+		{"1 212 555 0100", "USA", "+12125550100", true},
+		{"030 1234567", "DE", "+49301234567", true},
+		{"06 1234 5678", "IT", "+390612345678", true},
+		{"55 1234 5678", "", "", false},
+		{"+52 55 1234 567", "", "", false},
+		{"+999 1234 5678", "", "", false},
+		{"55-1234-ABCD", "MX", "", false},
+		{"+1 234", "", "", false},
+	}
+
+	for i, test := range tests {
+		result, valid := normalizePhone(test.input, test.option)
+		if valid != test.valid || result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\" (%v), Recived: \"%s\" (%v)", i, test.expected, test.valid, result, valid)
+		}
+	}
+}
+
+func TestNormalizeZip(t *testing.T) {
+
+	tests := []normalizeTests{
+		{"06600", "MX", "06600", true},
+		{"066000", "MX", "", false},
+		{"94105", "MX|US", "94105", true},
+		{"941051234", "US", "94105-1234", true},
+		{"94105-1234", "MX|US", "94105-1234", true},
+		{"k1a0b1", "CA", "K1A 0B1", true},
+		{"K1A 0B1", "MX|US", "", false},
+	}
+
+	for i, test := range tests {
+		result, valid := normalizeZip(test.input, strings.Split(test.option, "|"))
+		if valid != test.valid || result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\" (%v), Recived: \"%s\" (%v)", i, test.expected, test.valid, result, valid)
+		}
+	}
+}
+
+func TestNormalizeCountryAndCurrency(t *testing.T) {
+
+	tests := []normalizeTests{
+		{"mx", "", "MX", true},
+		{"MEX", "", "MX", true},
+		{"mx", "alpha3", "MEX", true},
+		{"UK", "", "", false},
+		{"ZZ", "", "", false},
+	}
+
+	for i, test := range tests {
+		result, valid := normalizeCountry(test.input, test.option)
+		if valid != test.valid || result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\" (%v), Recived: \"%s\" (%v)", i, test.expected, test.valid, result, valid)
+		}
+	}
+
+	currencyTests := []normalizeTests{
+		{"mxn", "", "MXN", true},
+		{" USD ", "", "USD", true},
+		{"DEM", "", "DEM", false},
+		{"ABC", "", "ABC", false},
+	}
+
+	for i, test := range currencyTests {
+		result, valid := normalizeCurrency(test.input)
+		if valid != test.valid || result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\" (%v), Recived: \"%s\" (%v)", i, test.expected, test.valid, result, valid)
+		}
+	}
+}
+
+type testContactRow struct {
+	Row
+	Phone      string `excelLayout:"column:A,phone:MX,normalize"`
+	RawPhone   string `excelLayout:"column:B,phone"`
+	Zip        string `excelLayout:"column:C,zip:MX|US,normalize"`
+	Country    string `excelLayout:"column:D,country:alpha3,normalize"`
+	Currency   string `excelLayout:"column:E,currency"`
+	NotChanged string `excelLayout:"column:F,country"`
+}
+
+func TestContactCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testContactRow{}
+	if errs := l.ParseCells(&row, []string{"55 1234 5678", "+1 212 555 0100", "941051234", "mx", "mxn", "mx"}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected := testContactRow{Phone: "+525512345678", RawPhone: "+1 212 555 0100", Zip: "94105-1234", Country: "MEX", Currency: "mxn", NotChanged: "mx"}
+	if row != expected {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, row)
+	}
+
+	errs := l.ParseCells(&testContactRow{}, []string{"5512", "5512345678", "K1A 0B1", "UK", "DEM", "MX"})
+	expectedErrs := []error{ErrPhoneRuleFail, ErrPhoneRuleFail, ErrZipRuleFail, ErrCountryRuleFail, ErrCurrencyRuleFail}
+	if len(errs) != len(expectedErrs) {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", expectedErrs, errs)
+	}
+	for i, e := range errs {
+		if e.Error != expectedErrs[i] {
+			t.Errorf("Test 1: Expected %v, Recived: %v", expectedErrs[i], e.Error)
+		}
+	}
+}
+
+func TestContactTagsParser(t *testing.T) {
+
+	tests := []struct {
+		tags     string
+		expected error
+	}{
+		{`excelLayout:"column:A,phone:MEX"`, nil},
+		{`excelLayout:"column:A,phone:XX"`, ErrTagUnknownCountry},
+		{`excelLayout:"column:A,zip"`, ErrTagMissingZipValue},
+		{`excelLayout:"column:A,zip:MX|FR"`, ErrTagUnknownZipCountry},
+		{`excelLayout:"column:A,country:numeric"`, ErrTagInvalidCountryFormat},
+	}
+
+	for i, test := range tests {
+		if _, err := parseOptions(test.tags); err != test.expected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package Layouts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var ErrScaleRuleFail error = errors.New("decimal scale rule fail")
+var ErrPrecisionRuleFail error = errors.New("decimal precision rule fail")
+var ErrTagMissingScaleValue error = errors.New("expected value for \"scale\" tag entry")
+var ErrTagMissingPrecisionValue error = errors.New("expected value for \"precision\" tag entry")
+var ErrTagInvalidPrecisionScaleValues error = errors.New("the \"precision\" value should be greater than \"scale\" value tag entry")
+
+/**
+ * Fixed point decimal number, stored as an unscaled integer and the number of
+ * decimal digits, for exact values like money amounts
+ */
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+/**
+ * Parse a plain decimal number like "-1234.50"
+ */
+func ParseDecimal(value string) (Decimal, error) {
+	value = strings.TrimSpace(value)
+	digits := value
+	scale := 0
+	if i := strings.Index(value, "."); i >= 0 {
+		digits = value[:i] + value[i+1:]
+		scale = len(value) - i - 1
+	}
+	if digits == "" || digits == "-" || digits == "+" || strings.ContainsAny(digits[1:], "+-") {
+		return Decimal{}, ErrDecimalInvalid
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, ErrDecimalInvalid
+	}
+	return Decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+func (d Decimal) value() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+/**
+ * Return the number of decimal digits
+ */
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+/**
+ * Return the number of digits, without leading zeros of the integer part
+ */
+func (d Decimal) Precision() int {
+	digits := len(new(big.Int).Abs(d.value()).String())
+	if digits < d.scale+1 {
+		return d.scale + 1
+	}
+	return digits
+}
+
+/**
+ * Return the decimal with the given number of decimal digits, false when
+ * digits different than zero have to be removed
+ */
+func (d Decimal) Rescale(scale int) (Decimal, bool) {
+	v := new(big.Int).Set(d.value())
+	if scale >= d.scale {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale-d.scale)), nil))
+		return Decimal{unscaled: v, scale: scale}, true
+	}
+	q, r := new(big.Int).QuoRem(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale-scale)), nil), new(big.Int))
+	return Decimal{unscaled: q, scale: scale}, r.Sign() == 0
+}
+
+/**
+ * Compare with other decimal, return -1, 0 or 1
+ */
+func (d Decimal) Cmp(o Decimal) int {
+	scale := d.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	a, _ := d.Rescale(scale)
+	b, _ := o.Rescale(scale)
+	return a.value().Cmp(b.value())
+}
+
+/**
+ * Return the closest float64 value
+ */
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.value(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.scale)), nil)).Float64()
+	return f
+}
+
+func (d Decimal) String() string {
+	v := d.value()
+	digits := new(big.Int).Abs(v).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
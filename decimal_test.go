@@ -0,0 +1,126 @@
+package Layouts
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type decimalTests struct {
+	input     string
+	expected  string
+	scale     int
+	precision int
+	err       error
+}
+
+func TestParseDecimal(t *testing.T) {
+
+	tests := []decimalTests{
+		{"0", "0", 0, 1, nil},
+		{"1234.50", "1234.50", 2, 6, nil},
+		{"-0.05", "-0.05", 2, 3, nil},
+		{"+12", "12", 0, 2, nil},
+		{".5", "0.5", 1, 2, nil},
+		{"12345678901234567890.123456789", "12345678901234567890.123456789", 9, 29, nil},
+		{"", "", 0, 0, ErrDecimalInvalid},
+		{"-", "", 0, 0, ErrDecimalInvalid},
+		{"1.2.3", "", 0, 0, ErrDecimalInvalid},
+		{"1-2", "", 0, 0, ErrDecimalInvalid},
+		{"1e5", "", 0, 0, ErrDecimalInvalid},
+	}
+
+	for i, test := range tests {
+		d, err := ParseDecimal(test.input)
+		if err != test.err {
+			t.Errorf("Test %d: Expected error %v, Recived: %v", i, test.err, err)
+		} else if err == nil && (d.String() != test.expected || d.Scale() != test.scale || d.Precision() != test.precision) {
+			t.Errorf("Test %d: Expected %s (%d, %d), Recived: %s (%d, %d)", i, test.expected, test.scale, test.precision, d.String(), d.Scale(), d.Precision())
+		}
+	}
+}
+
+func TestDecimalOperations(t *testing.T) {
+	a, _ := ParseDecimal("10.10")
+	b, _ := ParseDecimal("10.1")
+	c, _ := ParseDecimal("10.11")
+
+	if a.Cmp(b) != 0 || a.Cmp(c) != -1 || c.Cmp(b) != 1 {
+		t.Errorf("Test 0: Unexpected comparison results")
+	}
+	if r, exact := b.Rescale(3); !exact || r.String() != "10.100" {
+		t.Errorf("Test 1: Unexpected rescale %s", r.String())
+	}
+	if r, exact := c.Rescale(1); exact || r.String() != "10.1" {
+		t.Errorf("Test 2: Unexpected rescale %s", r.String())
+	}
+	if f := c.Float64(); f != 10.11 {
+		t.Errorf("Test 3: Expected 10.11, Recived: %v", f)
+	}
+
+	v := struct{ Amount Decimal }{}
+	if err := json.Unmarshal([]byte(`{"Amount":"0.30"}`), &v); err != nil || v.Amount.String() != "0.30" {
+		t.Errorf("Test 4: Unexpected unmarshal %s %v", v.Amount.String(), err)
+	}
+	if b, _ := json.Marshal(v); string(b) != `{"Amount":"0.30"}` {
+		t.Errorf("Test 5: Unexpected marshal %s", string(b))
+	}
+}
+
+type testStatus string
+
+func (s *testStatus) UnmarshalText(text []byte) error {
+	*s = testStatus(strings.ToLower(string(text)))
+	if *s != "open" && *s != "closed" {
+		return ErrTextValueInvalid
+	}
+	return nil
+}
+
+type testInvoiceRow struct {
+	Row
+	Amount   Decimal    `excelLayout:"column:A,required,scale:2,precision:8,min:0.01,max:99999.99"`
+	Tax      Decimal    `excelLayout:"column:B,locale:es-MX,scale:4"`
+	Status   testStatus `excelLayout:"column:C,required"`
+	Discount Decimal    `excelLayout:"column:D,default:0"`
+}
+
+func TestDecimalCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testInvoiceRow{}
+	if errs := l.ParseCells(&row, []string{"1500.5", "$ 1,240.1234", "OPEN", ""}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	if row.Amount.String() != "1500.50" || row.Tax.String() != "1240.1234" || row.Status != "open" || row.Discount.String() != "0" {
+		t.Errorf("Test 0: Unexpected row values %v %v %v %v", row.Amount, row.Tax, row.Status, row.Discount)
+	}
+
+	tests := [][]string{
+		{"0.001", "0", "open"},
+		{"0.00", "0", "open"},
+		{"1000000", "0", "open"},
+		{"1", "0", "pending"},
+	}
+	expected := [][]error{
+		{ErrScaleRuleFail, ErrMinValueRuleFail},
+		{ErrMinValueRuleFail},
+		{ErrPrecisionRuleFail, ErrMaxValueRuleFail},
+		{ErrTextValueInvalid},
+	}
+	for i, test := range tests {
+		row := testInvoiceRow{}
+		errs := l.ParseCells(&row, test)
+		if len(errs) != len(expected[i]) {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i+1, expected[i], errs)
+			continue
+		}
+		for j, e := range errs {
+			if e.Error != expected[i][j] {
+				t.Errorf("Test %d: Expected %v, Recived: %v", i+1, expected[i][j], e.Error)
+			}
+		}
+	}
+}
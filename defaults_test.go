@@ -0,0 +1,96 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testDefaultRow struct {
+	Row
+	Name    string    `excelLayout:"column:A,required,default:Anónimo"`
+	Age     int       `excelLayout:"column:B,required,min:18,default:18"`
+	Balance float64   `excelLayout:"column:C,default:0.5"`
+	Tags    []string  `excelLayout:"column:D,commaSeparatedValue,default:new|pending"`
+	Since   time.Time `excelLayout:"column:E,format:02/01/2006,default:01/01/2022"`
+}
+
+func TestDefaultCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testDefaultRow{}
+	if errs := l.ParseCells(&row, []string{" ", "", "", "", ""}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected := testDefaultRow{
+		Name: "Anónimo", Age: 18, Balance: 0.5, Tags: []string{"new", "pending"},
+		Since: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Test 0: Expected %+v, Recived: %+v", expected, row)
+	}
+
+	row = testDefaultRow{}
+	if errs := l.ParseCells(&row, []string{"Artziel", "44", "10", "vip", "15/03/2021"}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 1: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected = testDefaultRow{
+		Name: "Artziel", Age: 44, Balance: 10, Tags: []string{"vip"},
+		Since: time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Test 1: Expected %+v, Recived: %+v", expected, row)
+	}
+
+	row = testDefaultRow{}
+	if errs := l.ParseCells(&row, []string{"Artziel", "44", "10", "vip", "2021-03-15"}); len(errs) != 1 || errs[0].Error != ErrDateInvalid {
+		t.Errorf("Test 2: Expected ErrDateInvalid, Recived: %v", errs)
+	}
+
+	row = testDefaultRow{}
+	if errs := l.ParseCells(&row, []string{"Artziel"}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 3: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected = testDefaultRow{
+		Name: "Artziel", Age: 18, Balance: 0.5, Tags: []string{"new", "pending"},
+		Since: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Test 3: Expected %+v, Recived: %+v", expected, row)
+	}
+}
+
+func TestDefaultStructParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	if errs := l.ParseStruct(testDefaultRow{Age: 20}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	if errs := l.ParseStruct(testDefaultRow{Age: 20, Since: time.Now()}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 1: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+}
+
+func TestDefaultSchema(t *testing.T) {
+	s := valueSchema(reflect.TypeOf(0), fieldTags{})
+	if s.Default != nil {
+		t.Errorf("Test 0: Unexpected default %v", s.Default)
+	}
+	for _, f := range schemaFieldsOf(testDefaultRow{}) {
+		if v := defaultValue(f.Type, f.Tags); f.Name == "Age" && v != int(18) {
+			t.Errorf("Test 1: Expected default 18, Recived: %v", v)
+		} else if f.Name == "Since" && v != "2022-01-01" {
+			t.Errorf("Test 2: Expected default \"2022-01-01\", Recived: %v", v)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package Layouts
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var ErrDetailFieldInvalid error = errors.New("the \"detail\" tag entry is only allowed on slices of structs")
+var ErrKeyFieldMissing error = errors.New("a \"key\" tag entry is required on the row type to group the detail rows")
+
+/**
+ * Return the detail field of a row type, if any
+ */
+func detailField(fields []layoutField) (layoutField, bool) {
+	for _, field := range fields {
+		if field.Tags.Detail {
+			return field, true
+		}
+	}
+	return layoutField{}, false
+}
+
+/**
+ * Return the key field of a row type, if any
+ */
+func keyField(fields []layoutField) (layoutField, bool) {
+	for _, field := range fields {
+		if field.Tags.Key {
+			return field, true
+		}
+	}
+	return layoutField{}, false
+}
+
+/**
+ * Return the detail items count errors of a record
+ */
+func detailCountErrors(field layoutField, rowIndex int, count int) []Error {
+	errors := []Error{}
+	tags := field.Tags
+	if tags.Required && count == 0 {
+		errors = append(errors, Error{RowIndex: rowIndex, Column: field.Name, Error: ErrRequiredValueRuleFail})
+	}
+	if tags.hasMinItems && count < int(tags.MinItems) {
+		errors = append(errors, Error{RowIndex: rowIndex, Column: field.Name, Error: ErrMinItemsRuleFail})
+	}
+	if tags.hasMaxItems && count > int(tags.MaxItems) {
+		errors = append(errors, Error{RowIndex: rowIndex, Column: field.Name, Error: ErrMaxItemsRuleFail})
+	}
+	return errors
+}
+
+/**
+ * Validate the detail items of a record value, the errors keep the item position
+ */
+func (l *ExcelLayout) parseDetailStruct(f reflect.Value, field layoutField, rowIndex int) []Error {
+	errors := []Error{}
+	for i := 0; i < f.Len(); i++ {
+		item := reflect.New(f.Type().Elem())
+		item.Elem().Set(f.Index(i))
+		for _, e := range l.ParseStruct(item.Interface()) {
+			e.Item = i + 1
+			errors = append(errors, e)
+		}
+	}
+	return append(errors, detailCountErrors(field, rowIndex, f.Len())...)
+}
+
+/**
+ * Record being grouped from consecutive rows with the same key
+ */
+type detailGroup struct {
+	Record   reflect.Value
+	RowIndex int
+	Key      string
+	Count    int
+}
+
+/**
+ * Parse the detail columns of a row and append the item to the record
+ * detail field, the rows with all the detail cells empty are not items. The
+ * pending lookups of the item keep its position to drop it when they fail
+ */
+func (l *ExcelLayout) parseDetailRow(group *detailGroup, field layoutField, childFields []layoutField, rowNumber int, cells []string) []Error {
+	empty := true
+	for _, child := range childFields {
+		if col := l.columnNumber(child); strings.TrimSpace(cellValue(cells, col)) != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+	group.Count++
+
+	f := group.Record.Elem().FieldByIndex(field.Index)
+	item := reflect.New(f.Type().Elem())
+	setRowIndex(item, rowNumber)
+	pending := 0
+	if l.lookups != nil {
+		pending = len(*l.lookups)
+	}
+	errs := l.parseFields(childFields, rowNumber, cells, func(child layoutField) reflect.Value {
+		return item.Elem().FieldByIndex(child.Index)
+	})
+	for i := range errs {
+		errs[i].Item = group.Count
+	}
+	if len(errs) == 0 {
+		f.Set(reflect.Append(f, item.Elem()))
+	}
+	if l.lookups != nil {
+		for i := pending; i < len(*l.lookups); i++ {
+			lk := &(*l.lookups)[i]
+			lk.Error.Item = group.Count
+			if len(errs) == 0 {
+				lk.detail = &detailItem{Field: f, Index: f.Len() - 1}
+			}
+		}
+	}
+	return errs
+}
+
+/**
+ * Read the file rows folding the consecutive rows with the same "key" field
+ * value into a record, the first row of every record is parsed with the
+ * record tags and every row with the detail field item tags. A row with an
+ * empty key continues the current record
+ */
+func (l *ExcelLayout) readDetailFile(elType reflect.Type, filePath string, fields []layoutField, detail layoutField) error {
+	key, found := keyField(fields)
+	if !found {
+		return ErrKeyFieldMissing
+	}
+	itemType := reflect.Indirect(reflect.New(elType)).FieldByIndex(detail.Index).Type()
+	if !isGroupType(itemType) {
+		return ErrDetailFieldInvalid
+	}
+	childFields := layoutFields(itemType.Elem())
+
+	groups := []*detailGroup{}
+	err := l.readFile(filePath, append(append([]layoutField{}, fields...), childFields...), func(rowNumber int, cells []string) (interface{}, []Error) {
+		value := strings.TrimSpace(cellValue(cells, l.columnNumber(key)))
+		if len(groups) > 0 {
+			current := groups[len(groups)-1]
+			if value == "" || value == current.Key {
+				return nil, l.parseDetailRow(current, detail, childFields, rowNumber, cells)
+			}
+		}
+
+		elItem := reflect.New(elType)
+		setRowIndex(elItem, rowNumber)
+		group := &detailGroup{Record: elItem, RowIndex: rowNumber, Key: value}
+		groups = append(groups, group)
+		errs := l.ParseCells(elItem.Interface(), cells)
+		errs = append(errs, l.parseDetailRow(group, detail, childFields, rowNumber, cells)...)
+		return elItem.Interface(), errs
+	})
+	if err != nil && err != ErrValidationFail {
+		return err
+	}
+
+	for _, group := range groups {
+		if errs := detailCountErrors(detail, group.RowIndex, group.Count); len(errs) > 0 {
+			err = ErrValidationFail
+			if !l.appendErrors(errs) {
+				return ErrValidationTruncated
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,109 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testOrderLine struct {
+	Row
+	Sku      string  `excelLayout:"column:D,required"`
+	Quantity int64   `excelLayout:"column:E,min:1"`
+	Price    float64 `excelLayout:"column:F"`
+}
+
+type testOrder struct {
+	Row
+	ID       string          `excelLayout:"column:A,key,required"`
+	Customer string          `excelLayout:"column:B,required"`
+	Date     string          `excelLayout:"column:C"`
+	Lines    []testOrderLine `excelLayout:"detail,minItems:1"`
+}
+
+func TestDetailFileRead(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Pedido", "Cliente", "Fecha", "SKU", "Cantidad", "Precio"},
+		{"P-1", "ACME", "2022-06-01"},
+		{"P-1", nil, nil, "A-100", 2, 10.5},
+		{"P-1", nil, nil, "B-200", 1, 3},
+		{"P-2", "Globex", "2022-06-02", "C-300", 5, 1},
+		{nil, nil, nil, "A-100", 1, 10.5},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testOrder{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+
+	expected := []interface{}{
+		&testOrder{Row: Row{Index: 2}, ID: "P-1", Customer: "ACME", Date: "2022-06-01", Lines: []testOrderLine{
+			{Row: Row{Index: 3}, Sku: "A-100", Quantity: 2, Price: 10.5},
+			{Row: Row{Index: 4}, Sku: "B-200", Quantity: 1, Price: 3},
+		}},
+		&testOrder{Row: Row{Index: 5}, ID: "P-2", Customer: "Globex", Date: "2022-06-02", Lines: []testOrderLine{
+			{Row: Row{Index: 5}, Sku: "C-300", Quantity: 5, Price: 1},
+			{Row: Row{Index: 6}, Sku: "A-100", Quantity: 1, Price: 10.5},
+		}},
+	}
+	if rows := l.GetRows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, rows)
+	}
+}
+
+func TestDetailFileErrors(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Pedido", "Cliente", "Fecha", "SKU", "Cantidad", "Precio"},
+		{"P-1", nil, "2022-06-01", "A-100", 0, 1},
+		{"P-1", nil, nil, nil, 2, 1},
+		{"P-2", "Globex", "2022-06-02"},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testOrder{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+
+	expected := []Error{
+		{RowIndex: 2, Column: "B", Error: ErrRequiredValueRuleFail},
+		{RowIndex: 2, Column: "E", Error: ErrMinValueRuleFail, Item: 1},
+		{RowIndex: 3, Column: "D", Error: ErrRequiredValueRuleFail, Item: 2},
+		{RowIndex: 4, Column: "Lines", Error: ErrMinItemsRuleFail},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+func TestDetailStructParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	order := testOrder{Row: Row{Index: 2}, ID: "P-1", Customer: "ACME", Lines: []testOrderLine{
+		{Row: Row{Index: 2}, Sku: "A-100", Quantity: 1},
+		{Row: Row{Index: 3}, Quantity: 1},
+	}}
+	expected := []Error{{RowIndex: 3, Column: "D", Error: ErrRequiredValueRuleFail, Item: 2}}
+	if errs := l.ParseStruct(&order); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+
+	order.Lines = nil
+	expected = []Error{{RowIndex: 2, Column: "Lines", Error: ErrMinItemsRuleFail}}
+	if errs := l.ParseStruct(&order); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+type testOrderWithoutKey struct {
+	Row
+	ID    string          `excelLayout:"column:A"`
+	Lines []testOrderLine `excelLayout:"detail"`
+}
+
+func TestDetailKeyMissing(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{{"Pedido"}, {"P-1"}})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testOrderWithoutKey{}, fileName); err != ErrKeyFieldMissing {
+		t.Errorf("Expected %v, Recived: %v", ErrKeyFieldMissing, err)
+	}
+}
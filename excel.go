@@ -2,7 +2,6 @@ package Layouts
 
 import (
 	"errors"
-	"fmt"
 	"reflect"
 	"strings"
 
@@ -11,69 +10,250 @@ import (
 
 var ErrNoSheetFound error = errors.New("no sheet found on file")
 var ErrValidationFail error = errors.New("file rows validation fail")
+var ErrValidationTruncated error = errors.New("file rows validation stopped, errors limit reached")
+
+/**
+ * Formula cells handling mode
+ */
+type FormulaMode int
+
+const (
+	FormulaCachedResult FormulaMode = iota // Read the result stored on the file
+	FormulaEvaluate                        // Calculate the formula result
+	FormulaReject                          // Report the formula cells as row errors
+)
 
 type ExcelLayout struct {
 	Layout
+	rangeRef  string
+	tableName string
+	headers   map[string]int
+	rawValues bool
+	formulas  FormulaMode
+	refs      map[string]map[string]bool
+	sheetRefs map[string]map[string]bool
+	resolver  Resolver
+	resolved  map[string]map[string]bool
+	lookups   *[]lookup
 }
 
-func (l *ExcelLayout) ParseStruct(r interface{}) []Error {
-	s := reflect.ValueOf(r)
+/**
+ * Read the underlying cell values instead of the formatted ones, so a number
+ * formatted as "12%" is read as "0.12" and a date as its serial number. The
+ * values are read without the layout locale since they always use the '.'
+ * decimal point, and the date fields accept the serial numbers
+ */
+func (l *ExcelLayout) RawValues() {
+	l.rawValues = true
+}
+
+/**
+ * Set how the formula cells are read, by default the cached result is used
+ */
+func (l *ExcelLayout) Formulas(mode FormulaMode) {
+	l.formulas = mode
+}
+
+/**
+ * Read the rows of an Excel table (ListObject) by name, the table header row
+ * is used to map the fields with "header" tag and the table area as row bounds
+ */
+func (l *ExcelLayout) Table(name string) {
+	l.tableName = strings.TrimSpace(name)
+}
+
+/**
+ * Set the sheet area where the table is positioned, as a range reference like
+ * "C5:K", "Hoja2!C5:K20" or a workbook defined name. The first range row is
+ * the header row and the first range column is read as column "A"
+ */
+func (l *ExcelLayout) Range(ref string) {
+	l.rangeRef = strings.TrimSpace(ref)
+}
+
+/**
+ * Resolve the configured range on the file, by default the whole first sheet
+ */
+func (l *ExcelLayout) resolveRange(xlsx *excelize.File) (cellRange, error) {
+	sheets := xlsx.GetSheetList()
+	if len(sheets) == 0 {
+		return cellRange{}, ErrNoSheetFound
+	}
+	if l.tableName != "" {
+		return findTableRange(xlsx, l.tableName)
+	}
+	if l.rangeRef == "" {
+		return cellRange{Sheet: sheets[0], FirstRow: 1, FirstCol: 1}, nil
+	}
+
+	ref := l.rangeRef
+	for _, dn := range xlsx.GetDefinedName() {
+		if strings.EqualFold(dn.Name, ref) {
+			ref = dn.RefersTo
+			break
+		}
+	}
+
+	r, err := parseRange(ref)
+	if err == ErrInvalidRange {
+		if r, err := findTableRange(xlsx, ref); err == nil {
+			return r, nil
+		}
+	}
+	if err != nil {
+		return r, err
+	}
+	if r.Sheet == "" {
+		r.Sheet = sheets[0]
+	} else if xlsx.GetSheetIndex(r.Sheet) == -1 {
+		return r, ErrSheetNotFound
+	}
+	return r, nil
+}
+
+/**
+ * Map the header row cells to their column number
+ */
+func (l *ExcelLayout) setHeaders(cells []string) {
+	l.headers = map[string]int{}
+	for i, c := range cells {
+		name := headerKey(c)
+		if _, exists := l.headers[name]; name != "" && !exists {
+			l.headers[name] = i + 1
+		}
+	}
+}
+
+/**
+ * Return the column number of a field, resolved by the "header" tag or the
+ * field name when the header row is known, or by the "column" tag otherwise
+ */
+func (l *ExcelLayout) columnNumber(field layoutField) int {
+	if field.Tags.Header != "" {
+		return l.headers[headerKey(field.Tags.Header)]
+	}
+	if field.Tags.Column == "" {
+		return l.headers[headerKey(field.Name)]
+	}
+	col, _ := excelize.ColumnNameToNumber(field.Tags.Column)
+	return col
+}
+
+/**
+ * Return an error for every field whose "header" tag is not found on the header
+ * row, and for the required fields read by their name without a default value
+ */
+func (l *ExcelLayout) checkHeaders(fields []layoutField, headerRow int) []Error {
 	errors := []Error{}
+	for _, field := range fields {
+		tags := field.Tags
+		header := tags.Header
+		if header == "" && tags.Column == "" && len(tags.Columns) == 0 && !tags.Detail && tags.Required && !tags.hasDefault {
+			header = field.Name
+		}
+		if header == "" {
+			continue
+		}
+		if _, exists := l.headers[headerKey(header)]; !exists {
+			errors = append(errors, Error{RowIndex: headerRow, Column: header, Error: ErrHeaderNotFound})
+		}
+	}
+	return errors
+}
+
+/**
+ * Evaluate or reject the formula cells of a row according to the formulas mode
+ */
+func (l *ExcelLayout) readFormulas(xlsx *excelize.File, bounds cellRange, rowNumber int, cells []string, fields []layoutField) ([]string, []Error) {
+	if l.formulas == FormulaCachedResult {
+		return cells, nil
+	}
 
-	for i := 0; i < s.NumField(); i++ {
-		tags, err := parseOptions(string(s.Type().Field(i).Tag))
-		if err == nil {
-			f := s.Field(i)
-			value := fmt.Sprintf("%v", f)
-			switch f.Kind() {
-			case reflect.Slice:
-				if tags.CommaSeparatedValue {
-					values := strings.Split(value, ",")
-					for _, v := range values {
-						switch reflect.TypeOf(f.Interface()).Elem().Kind() {
-						case reflect.String:
-							if _, err := parseStringRules(v, tags); err != nil {
-								for _, e := range err {
-									errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-								}
-							}
-						case reflect.Float32, reflect.Float64:
-							if _, err := parseFloat64Rules(v, tags); err != nil {
-								for _, e := range err {
-									errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-								}
-							}
-						case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-							if _, err := parseIntRules(v, tags); err != nil {
-								for _, e := range err {
-									errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-								}
-							}
-						}
-					}
-				} else {
-					errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: ErrCommaSeparatedInvalid})
-				}
-			case reflect.String:
-				if _, err := parseStringRules(value, tags); err != nil {
-					for _, e := range err {
-						errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-					}
-				}
-			case reflect.Float32, reflect.Float64:
-				if _, err := parseFloat64Rules(value, tags); err != nil {
-					for _, e := range err {
-						errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-					}
-				}
-			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-				if _, err := parseIntRules(value, tags); err != nil {
-					for _, e := range err {
-						errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-					}
-				}
+	length := len(cells)
+	width := length
+	for _, field := range fields {
+		if col := l.columnNumber(field); col > width {
+			width = col
+		}
+		for _, s := range field.Tags.Columns {
+			if s.Last > width {
+				width = s.Last
 			}
+		}
+	}
+	for len(cells) < width {
+		cells = append(cells, "")
+	}
+
+	errors := []Error{}
+	for i := range cells {
+		axis, _ := excelize.CoordinatesToCellName(bounds.FirstCol+i, rowNumber)
+		if formula, _ := xlsx.GetCellFormula(bounds.Sheet, axis); formula == "" {
+			continue
+		}
+		column, _ := excelize.ColumnNumberToName(i + 1)
+		if l.formulas == FormulaReject {
+			errors = append(errors, Error{RowIndex: rowNumber, Column: column, Error: ErrFormulaRejected})
+			continue
+		}
+		value, err := xlsx.CalcCellValue(bounds.Sheet, axis)
+		if err != nil {
+			errors = append(errors, Error{RowIndex: rowNumber, Column: column, Error: ErrFormulaInvalid})
+			continue
+		}
+		cells[i] = value
+	}
+	for len(cells) > length && cells[len(cells)-1] == "" {
+		cells = cells[:len(cells)-1]
+	}
+
+	return cells, errors
+}
+
+/**
+ * Append the row errors to the cell errors, skipping the columns that already have a cell error
+ */
+func mergeErrors(cellErrs []Error, rowErrs []Error) []Error {
+	columns := map[string]bool{}
+	for _, e := range cellErrs {
+		columns[e.Column] = true
+	}
+	for _, e := range rowErrs {
+		if !columns[e.Column] {
+			cellErrs = append(cellErrs, e)
+		}
+	}
+	return cellErrs
+}
+
+func (l *ExcelLayout) ParseStruct(r interface{}) []Error {
+	s := reflect.Indirect(reflect.ValueOf(r))
+	errors := []Error{}
 
+	rowIndex := getRowIndex(s)
+	for _, field := range layoutFields(s.Type()) {
+		f := s.FieldByIndex(field.Index)
+		tags := field.Tags
+		tags.Locale = ""
+		if tags.Detail {
+			errors = append(errors, l.parseDetailStruct(f, field, rowIndex)...)
+			continue
+		}
+		if len(tags.Columns) > 0 {
+			errors = append(errors, l.parseColumns(reflect.New(f.Type()).Elem(), tags, rowIndex, columnCells(f, tags))...)
+			continue
+		}
+		err := parseValue(reflect.New(f.Type()).Elem(), formatValue(f, tags), tags)
+		if err == nil && tags.Ref != "" {
+			err = l.checkRef(f, tags)
+		}
+		if err == nil && tags.Exists != "" {
+			errors = append(errors, l.resolveRowLookups(fieldLookups(f, tags, rowIndex))...)
+		}
+		if err != nil {
+			for _, e := range err {
+				errors = append(errors, newError(rowIndex, field.Tags.Column, e))
+			}
 		}
 	}
 
@@ -85,98 +265,73 @@ func (l *ExcelLayout) ParseStruct(r interface{}) []Error {
 }
 
 func (l *ExcelLayout) ParseCells(r interface{}, cells []string) []Error {
-	if l.uniques == nil {
-		l.uniques = map[string]int{}
-	}
+	s := reflect.ValueOf(r).Elem()
+
+	return l.parseFields(layoutFields(s.Type()), getRowIndex(s), cells, func(field layoutField) reflect.Value {
+		return s.FieldByIndex(field.Index)
+	})
+}
 
+/**
+ * Parse the row cells of every field into the value returned by target
+ */
+func (l *ExcelLayout) parseFields(fields []layoutField, rowIndex int, cells []string, target func(layoutField) reflect.Value) []Error {
 	errors := []Error{}
+	lookups := []lookup{}
 
-	s := reflect.ValueOf(r)
-
-	for i := 0; i < s.Elem().NumField(); i++ {
-		rowIndex := int(s.Elem().FieldByName("Index").Int())
-		tags, err := parseOptions(string(s.Elem().Type().Field(i).Tag))
-		if err == nil {
-			f := s.Elem().Field(i)
-			col, _ := excelize.ColumnNameToNumber(tags.Column)
-			col--
-			if col >= 0 && col <= len(cells)-1 {
-
-				value := cells[col]
-
-				switch f.Kind() {
-				case reflect.Slice:
-					if tags.CommaSeparatedValue {
-						values := strings.Split(value, ",")
-						for _, v := range values {
-							switch reflect.TypeOf(f.Interface()).Elem().Kind() {
-							case reflect.String:
-								if val, err := parseStringRules(v, tags); err != nil {
-									for _, e := range err {
-										errors = append(errors, Error{RowIndex: rowIndex, Column: tags.Column, Error: e})
-									}
-								} else {
-									f.Set(reflect.Append(f, reflect.ValueOf(val)))
-								}
-							case reflect.Float32, reflect.Float64:
-								if val, err := parseFloat64Rules(v, tags); err != nil {
-									for _, e := range err {
-										errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-									}
-								} else {
-									f.Set(reflect.Append(f, reflect.ValueOf(val)))
-								}
-							case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-								if val, err := parseIntRules(v, tags); err != nil {
-									for _, e := range err {
-										errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-									}
-								} else {
-									f.Set(reflect.Append(f, reflect.ValueOf(val)))
-								}
-							}
-						}
-					} else {
-						errors = append(errors, Error{RowIndex: rowIndex, Column: tags.Column, Error: ErrCommaSeparatedInvalid})
-					}
-				case reflect.String:
-					if val, err := parseStringRules(value, tags); err != nil {
-						for _, e := range err {
-							errors = append(errors, Error{RowIndex: rowIndex, Error: e, Column: tags.Column})
-						}
-					} else {
-						f.SetString(val)
-					}
-				case reflect.Float32, reflect.Float64:
-					if val, err := parseFloat64Rules(value, tags); err != nil {
-						for _, e := range err {
-							errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-						}
-					} else {
-						f.SetFloat(float64(val))
-					}
-				case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-					if val, err := parseIntRules(value, tags); err != nil {
-						for _, e := range err {
-							errors = append(errors, Error{RowIndex: 0, Column: tags.Column, Error: e})
-						}
-					} else {
-						f.SetInt(int64(val))
-					}
-				}
-
-				if tags.Unique {
-					if _, exists := l.uniques[tags.Column]; exists {
-						errors = append(errors, Error{RowIndex: rowIndex, Error: ErrNotUnique, Column: tags.Column})
-					} else {
-						l.uniques[tags.Column] = rowIndex
-					}
-				}
-			}
+	for _, field := range fields {
+		tags := field.Tags
+		if tags.Locale == "" {
+			tags.Locale = l.locale
+		}
+		if l.rawValues {
+			// The raw values are written with the '.' decimal point
+			tags.Locale = ""
+			tags.serialDates = true
+		}
+		if tags.Detail {
+			continue
+		}
+		if len(tags.Columns) > 0 {
+			errors = append(errors, l.parseColumns(target(field), tags, rowIndex, cells)...)
+			continue
+		}
+		col := l.columnNumber(field) - 1
+		if col < 0 || (col >= len(cells) && !tags.hasDefault) {
+			continue
+		}
+		if tags.Column == "" {
+			tags.Column, _ = excelize.ColumnNumberToName(col + 1)
+		}
+		// The trailing blank cells are not read, they are empty values for
+		// the fields with a default value
+		value := ""
+		if col < len(cells) {
+			value = cells[col]
+		}
+
+		err := parseValue(target(field), value, tags)
+		if err == nil && tags.Ref != "" {
+			err = l.checkRef(target(field), tags)
+		}
+		if err == nil && tags.Exists != "" {
+			lookups = append(lookups, fieldLookups(target(field), tags, rowIndex)...)
+		}
+		for _, e := range err {
+			errors = append(errors, newError(rowIndex, tags.Column, e))
+		}
 
+		if tags.Unique && len(err) == 0 && !l.isUniqueValue(tags.Column, rowIndex, target(field), value) {
+			errors = append(errors, Error{RowIndex: rowIndex, Error: ErrNotUnique, Column: tags.Column})
 		}
 	}
 
+	if l.lookups != nil {
+		*l.lookups = append(*l.lookups, lookups...)
+	} else {
+		errors = append(errors, l.resolveRowLookups(lookups)...)
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -185,43 +340,131 @@ func (l *ExcelLayout) ParseCells(r interface{}, cells []string) []Error {
 }
 
 func (l *ExcelLayout) ReadFile(rowType interface{}, filePath string) error {
+	elType := reflect.TypeOf(rowType)
+	fields := layoutFields(elType)
+	if detail, found := detailField(fields); found {
+		return l.readDetailFile(elType, filePath, fields, detail)
+	}
+
+	return l.readFile(filePath, fields, func(rowNumber int, cells []string) (interface{}, []Error) {
+		elItem := reflect.New(elType).Interface()
+		setRowIndex(reflect.ValueOf(elItem), rowNumber)
+		return elItem, l.ParseCells(elItem, cells)
+	})
+}
+
+/**
+ * Open the workbook file, the Excel 97-2003 workbooks and the OpenDocument
+ * spreadsheets are detected by content and read as an Excel workbook
+ */
+func (l *ExcelLayout) openFile(filePath string) (*excelize.File, error) {
+	if isXlsFile(filePath) {
+		return openXlsFile(filePath)
+	}
+	if isOdsFile(filePath) {
+		return openOdsFile(filePath, l.rawValues)
+	}
+	return excelize.OpenFile(filePath)
+}
+
+/**
+ * Read the file rows inside the configured bounds, every data row is parsed
+ * by parseRow and the result stored on the layout rows, a nil result is not
+ * stored
+ */
+func (l *ExcelLayout) readFile(filePath string, fields []layoutField, parseRow func(int, []string) (interface{}, []Error)) error {
 
 	hasErrors := false
-	elType := reflect.TypeOf(rowType)
 	elSlice := []interface{}{}
 
-	xlsx, err := excelize.OpenFile(filePath)
+	xlsx, err := l.openFile(filePath)
 	if err != nil {
 		return err
 	}
 	defer func() {
 		xlsx.Close()
 	}()
-	sheets := xlsx.GetSheetList()
-
-	if len(sheets) == 0 {
-		return ErrNoSheetFound
+	bounds, err := l.resolveRange(xlsx)
+	if err != nil {
+		return err
+	}
+	if err := l.loadRefs(xlsx, fields); err != nil {
+		return err
 	}
 
-	// Get all the rows in the Sheet1.
-	rows, err := xlsx.GetRows(sheets[0])
+	rows, err := xlsx.GetRows(bounds.Sheet, excelize.Options{RawCellValue: l.rawValues})
 	if err != nil {
 		return err
 	}
+	headerRow := l.getHeaderRow(bounds)
+	if headerRow > 0 && headerRow <= len(rows) {
+		l.setHeaders(bounds.cells(rows[headerRow-1]))
+	} else {
+		l.headers = map[string]int{}
+	}
+	if err := l.checkHeaders(fields, headerRow); len(err) > 0 {
+		l.appendErrors(err)
+		return ErrValidationFail
+	}
+
+	rowErrors := []Error{}
+	stopped := false
+	lookups := []lookup{}
+	l.lookups = &lookups
+	defer func() {
+		l.lookups = nil
+	}()
+
+	firstDataRow := l.getFirstDataRow(bounds)
 	for i, row := range rows {
-		if i > 0 {
-			elItem := reflect.New(elType).Interface()
-			f := reflect.Indirect(reflect.ValueOf(elItem)).FieldByName("Index")
-			f.SetInt(int64(i) + 1)
-
-			if err := l.ParseCells(elItem, row); err != nil {
-				hasErrors = true
-				l.errors = append(l.errors, err...)
-			}
+		rowNumber := i + 1
+		if rowNumber < firstDataRow || rowNumber < bounds.FirstRow {
+			continue
+		}
+		if !bounds.containsRow(rowNumber) {
+			break
+		}
+		row = bounds.cells(row)
+		if l.isAfterData(rowNumber, row) {
+			break
+		}
+		if l.isSkippedRow(row) {
+			continue
+		}
+		if l.isErrorsLimitReached(len(rowErrors)) {
+			stopped = true
+			break
+		}
+
+		row, formulaErrs := l.readFormulas(xlsx, bounds, rowNumber, row, fields)
+		elItem, err := parseRow(rowNumber, row)
+		err = mergeErrors(formulaErrs, err)
+		if len(err) > 0 {
+			hasErrors = true
+			rowErrors = append(rowErrors, l.rowErrors(err)...)
+		}
+		if elItem != nil {
 			elSlice = append(elSlice, elItem)
 		}
 	}
+
+	failed, err := l.resolveLookups(lookups)
+	if err != nil {
+		return err
+	}
+	dropDetailItems(failed)
+	if len(failed) > 0 {
+		hasErrors = true
+		rowErrors = append(rowErrors, lookupErrors(failed)...)
+	}
+	if !l.appendRowErrors(rowErrors) {
+		stopped = true
+	}
+
 	l.rows = elSlice
+	if stopped {
+		return ErrValidationTruncated
+	}
 	if hasErrors {
 		return ErrValidationFail
 	}
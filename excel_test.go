@@ -1,10 +1,32 @@
 package Layouts
 
 import (
+	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/xuri/excelize/v2"
 )
 
+/**
+ * Create a temporal xlsx file with the given rows on the first sheet
+ */
+func createTestFile(t *testing.T, rows [][]interface{}) string {
+	xlsx := excelize.NewFile()
+	sheet := xlsx.GetSheetName(0)
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := xlsx.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatalf("Unable to write test row %d: %s", i, err.Error())
+		}
+	}
+	fileName := filepath.Join(t.TempDir(), "test.xlsx")
+	if err := xlsx.SaveAs(fileName); err != nil {
+		t.Fatalf("Unable to save test file: %s", err.Error())
+	}
+	return fileName
+}
+
 func TestExcelRowCellsParser(t *testing.T) {
 
 	tests := []RowParserTests{
@@ -148,3 +170,152 @@ func TestExcelFileRead(t *testing.T) {
 		t.Errorf("Test 1: Expected one row, Recived: %d", l.CountRows())
 	}
 }
+
+func TestExcelFileReadErrorLimits(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"ID", "Username", "Password", "Avatar", "Fullname", "Email", "Age", "Key"},
+		{"0", "xxx", "123", "asd", "Artziel Narvaiza", "xxx@yyy.com", "10", "peach"},
+		{"0", "xxx", "123", "asd", "Artziel Narvaiza", "yyy@yyy.com", "10", "peach"},
+		{"0", "xxx", "123", "asd", "Artziel Narvaiza", "zzz@yyy.com", "10", "peach"},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(TestRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 0: Expected ErrValidationFail, Recived: %v", err)
+	} else if l.IsTruncated() {
+		t.Errorf("Test 0: Errors should not be truncated")
+	}
+	total := len(l.GetErrors())
+
+	l = ExcelLayout{}
+	l.StopOnFirstError()
+	if err := l.ReadFile(TestRow{}, fileName); err != ErrValidationTruncated {
+		t.Errorf("Test 1: Expected ErrValidationTruncated, Recived: %v", err)
+	} else if len(l.GetErrors()) != 1 || !l.IsTruncated() {
+		t.Errorf("Test 1: Expected 1 truncated error, Recived: %d", len(l.GetErrors()))
+	} else if l.CountRows() != 1 {
+		t.Errorf("Test 1: Expected 1 row, Recived: %d", l.CountRows())
+	}
+
+	l = ExcelLayout{}
+	l.MaxErrors(7)
+	if err := l.ReadFile(TestRow{}, fileName); err != ErrValidationTruncated {
+		t.Errorf("Test 2: Expected ErrValidationTruncated, Recived: %v", err)
+	} else if len(l.GetErrors()) != 7 {
+		t.Errorf("Test 2: Expected 7 errors, Recived: %d", len(l.GetErrors()))
+	} else if l.CountRows() != 2 {
+		t.Errorf("Test 2: Expected 2 rows, Recived: %d", l.CountRows())
+	}
+
+	l = ExcelLayout{}
+	l.MaxErrorsPerRow(2)
+	if err := l.ReadFile(TestRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 3: Expected ErrValidationFail, Recived: %v", err)
+	} else if len(l.GetErrors()) != 6 || !l.IsTruncated() {
+		t.Errorf("Test 3: Expected 6 truncated errors, Recived: %d", len(l.GetErrors()))
+	}
+
+	l = ExcelLayout{}
+	l.MaxErrors(total)
+	if err := l.ReadFile(TestRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 4: Expected ErrValidationFail, Recived: %v", err)
+	} else if len(l.GetErrors()) != total || l.IsTruncated() {
+		t.Errorf("Test 4: Expected %d errors not truncated, Recived: %d", total, len(l.GetErrors()))
+	}
+}
+
+type testProductRow struct {
+	Row
+	Code  string  `excelLayout:"column:A,required"`
+	Price float64 `excelLayout:"column:B,required,min:0"`
+}
+
+func TestExcelFileReadRowBounds(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Products report"},
+		{"Generated by the system"},
+		{"Code", "Price"},
+		{"A-1", "10.5"},
+		{" ", " "},
+		{"A-2", "20"},
+		{"TOTAL", "30.5"},
+		{"Printed by admin"},
+	})
+
+	l := ExcelLayout{}
+	l.HeaderRow(3)
+	l.SkipBlankRows()
+	l.FooterMarker("total")
+	if err := l.ReadFile(testProductRow{}, fileName); err != nil {
+		t.Errorf("Test 0: Unexpected error: %s", err.Error())
+	} else if l.CountRows() != 2 {
+		t.Errorf("Test 0: Expected 2 rows, Recived: %d", l.CountRows())
+	} else if row := l.GetRows()[1].(*testProductRow); row.Index != 6 || row.Code != "A-2" {
+		t.Errorf("Test 0: Expected code \"A-2\" at row 6, Recived: \"%s\" at row %d", row.Code, row.Index)
+	}
+
+	l = ExcelLayout{}
+	l.FirstDataRow(4)
+	l.LastDataRow(6)
+	if err := l.ReadFile(testProductRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 1: Expected ErrValidationFail, Recived: %v", err)
+	} else if l.CountRows() != 3 {
+		t.Errorf("Test 1: Expected 3 rows, Recived: %d", l.CountRows())
+	} else if errs := l.GetErrors(); errs[0].RowIndex != 5 {
+		t.Errorf("Test 1: Expected errors on row 5, Recived: %d", errs[0].RowIndex)
+	}
+
+	fileName = createTestFile(t, [][]interface{}{
+		{"Code", "Price", "Notes"},
+		{"A-1", "10.5", "TOTAL"},
+		{"A-2", "20", "Total"},
+		{"", " TOTAL ", "30.5"},
+		{"A-3", "1"},
+	})
+	l = ExcelLayout{}
+	l.FooterMarker("TOTAL")
+	if err := l.ReadFile(testProductRow{}, fileName); err != nil {
+		t.Errorf("Test 2: Unexpected error: %s", err.Error())
+	} else if l.CountRows() != 2 || l.GetRows()[1].(*testProductRow).Code != "A-2" {
+		t.Errorf("Test 2: Expected the rows A-1 and A-2, Recived: %v", l.GetRows())
+	}
+}
+
+func TestExcelFileReadRange(t *testing.T) {
+	xlsx := excelize.NewFile()
+	sheet := xlsx.GetSheetName(0)
+	xlsx.SetSheetRow(sheet, "A1", &[]interface{}{"Notes"})
+	xlsx.SetSheetRow(sheet, "C5", &[]interface{}{"Code", "Price", "Comments"})
+	xlsx.SetSheetRow(sheet, "A6", &[]interface{}{"ignored", "", "A-1", "10", "ok"})
+	xlsx.SetSheetRow(sheet, "C7", &[]interface{}{"A-2", "20"})
+	xlsx.SetSheetRow(sheet, "C8", &[]interface{}{"", "-1"})
+	xlsx.SetDefinedName(&excelize.DefinedName{Name: "Products", RefersTo: sheet + "!$C$5:$D$7"})
+	fileName := filepath.Join(t.TempDir(), "range.xlsx")
+	if err := xlsx.SaveAs(fileName); err != nil {
+		t.Fatalf("Unable to save test file: %s", err.Error())
+	}
+
+	l := ExcelLayout{}
+	l.Range("C5:D")
+	if err := l.ReadFile(testProductRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 0: Expected ErrValidationFail, Recived: %v", err)
+	} else if l.CountRows() != 3 {
+		t.Errorf("Test 0: Expected 3 rows, Recived: %d", l.CountRows())
+	} else if row := l.GetRows()[0].(*testProductRow); row.Code != "A-1" || row.Price != 10 {
+		t.Errorf("Test 0: Unexpected row values %+v", row)
+	}
+
+	l = ExcelLayout{}
+	l.Range("products")
+	if err := l.ReadFile(testProductRow{}, fileName); err != nil {
+		t.Errorf("Test 1: Unexpected error: %s", err.Error())
+	} else if l.CountRows() != 2 {
+		t.Errorf("Test 1: Expected 2 rows, Recived: %d", l.CountRows())
+	}
+
+	l = ExcelLayout{}
+	l.Range("Unknown!C5:D")
+	if err := l.ReadFile(testProductRow{}, fileName); err != ErrSheetNotFound {
+		t.Errorf("Test 2: Expected ErrSheetNotFound, Recived: %v", err)
+	}
+}
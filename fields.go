@@ -0,0 +1,224 @@
+package Layouts
+
+import (
+	"encoding"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+var decimalType = reflect.TypeOf(Decimal{})
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+var ErrRowsNotSlice error = errors.New("expected a slice of rows")
+
+/**
+ * Check if the struct type is read from a single cell instead of mapped as a group of columns
+ */
+func isValueType(t reflect.Type) bool {
+	return t == timeType || reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+/**
+ * Layout field found on a row type, nested and embedded structs are flattened
+ * and their tags resolved with the group column offset and header prefix
+ */
+type layoutField struct {
+	Index []int
+	Name  string
+	Tags  fieldTags
+}
+
+/**
+ * Return the layout fields of a row type
+ */
+func layoutFields(t reflect.Type) []layoutField {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return appendLayoutFields([]layoutField{}, t, []int{}, 0, "")
+}
+
+func appendLayoutFields(fields []layoutField, t reflect.Type, index []int, offset int, prefix string) []layoutField {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		fieldIndex := append(append([]int{}, index...), i)
+		tags, err := parseOptions(string(sf.Tag))
+
+		if sf.Type.Kind() == reflect.Struct && !isValueType(sf.Type) && tags.Column == "" && tags.Header == "" {
+			groupOffset := offset
+			if col, err := excelize.ColumnNameToNumber(tags.Offset); err == nil {
+				groupOffset += col - 1
+			}
+			groupPrefix := prefix
+			if !sf.Anonymous && err != ErrTagNoFieldTag {
+				groupPrefix += tags.Prefix
+			}
+			fields = appendLayoutFields(fields, sf.Type, fieldIndex, groupOffset, groupPrefix)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		if tags.Column != "" && offset > 0 {
+			col, _ := excelize.ColumnNameToNumber(tags.Column)
+			tags.Column, _ = excelize.ColumnNumberToName(col + offset)
+		}
+		if len(tags.Columns) > 0 && offset > 0 {
+			spans := []columnSpan{}
+			for _, s := range tags.Columns {
+				spans = append(spans, columnSpan{First: s.First + offset, Last: s.Last + offset})
+			}
+			tags.Columns = spans
+		}
+		if tags.Header != "" {
+			tags.Header = prefix + tags.Header
+		}
+		fields = append(fields, layoutField{Index: fieldIndex, Name: prefix + sf.Name, Tags: tags})
+	}
+	return fields
+}
+
+/**
+ * Return the Index field of the Row struct embedded on a row value
+ */
+func rowIndexField(v reflect.Value) (reflect.Value, bool) {
+	v = reflect.Indirect(v)
+	rowType := reflect.TypeOf(Row{})
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		if !sf.Anonymous || sf.Type.Kind() != reflect.Struct {
+			continue
+		}
+		if sf.Type == rowType {
+			return v.Field(i).Field(0), true
+		}
+		if f, found := rowIndexField(v.Field(i)); found {
+			return f, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+/**
+ * Set the row number on a row value
+ */
+func setRowIndex(v reflect.Value, rowIndex int) {
+	if f, found := rowIndexField(v); found {
+		f.SetInt(int64(rowIndex))
+	}
+}
+
+/**
+ * Return the row number of a row value
+ */
+func getRowIndex(v reflect.Value) int {
+	if f, found := rowIndexField(v); found {
+		return int(f.Int())
+	}
+	return 0
+}
+
+/**
+ * Return the struct values of a slice of rows, the rows can be structs,
+ * pointers to structs or interfaces holding them
+ */
+func rowValues(rows interface{}) ([]reflect.Value, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return nil, ErrRowsNotSlice
+	}
+	values := []reflect.Value{}
+	for i := 0; i < v.Len(); i++ {
+		row := v.Index(i)
+		for row.Kind() == reflect.Interface || row.Kind() == reflect.Ptr {
+			row = row.Elem()
+		}
+		if row.Kind() != reflect.Struct {
+			return nil, ErrRowsNotSlice
+		}
+		values = append(values, row)
+	}
+	return values, nil
+}
+
+/**
+ * Header name normalized for comparisons, case and spaces are ignored
+ */
+func headerKey(name string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(name), ""))
+}
+
+/**
+ * Parse the string value applying the field rules and assign it to the field
+ */
+func parseValue(f reflect.Value, value string, tags fieldTags) []error {
+	if tags.hasDefault && strings.TrimSpace(value) == "" {
+		value = tags.Default
+		if f.Kind() == reflect.Slice {
+			value = strings.ReplaceAll(value, "|", tags.separator())
+		}
+	}
+
+	if f.Kind() == reflect.Slice {
+		return parseSliceValue(f, value, tags)
+	}
+
+	value = applyTransforms(value, tags.Transforms)
+
+	switch {
+	case f.Type() == decimalType:
+		val, err := parseDecimalRules(value, tags)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(val))
+		return nil
+	case f.Type() != timeType && f.CanAddr() && f.Addr().Type().Implements(textUnmarshalerType):
+		return parseTextRules(f.Addr().Interface().(encoding.TextUnmarshaler), value, tags)
+	}
+
+	switch f.Kind() {
+	case reflect.String:
+		val, err := parseStringRules(value, tags)
+		if err != nil {
+			return err
+		}
+		f.SetString(val)
+	case reflect.Float32, reflect.Float64:
+		val, err := parseFloat64Rules(value, tags)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(val)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, err := parseIntRules(value, tags)
+		if err != nil {
+			return err
+		}
+		f.SetInt(val)
+	case reflect.Bool:
+		val, err := parseBoolRules(value, tags)
+		if err != nil {
+			return err
+		}
+		f.SetBool(val)
+	case reflect.Struct:
+		if f.Type() == timeType {
+			val, err := parseDateRules(value, tags)
+			if err != nil {
+				return err
+			}
+			f.Set(reflect.ValueOf(val))
+		}
+	}
+	return nil
+}
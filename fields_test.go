@@ -0,0 +1,82 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testAddress struct {
+	Street string `excelLayout:"column:A,required"`
+	City   string `excelLayout:"column:B,required"`
+}
+
+type testContact struct {
+	Email string `excelLayout:"header:Email,email"`
+}
+
+type testAudit struct {
+	CreatedBy string `excelLayout:"column:Z"`
+}
+
+type testCustomerRow struct {
+	Row
+	testContact
+	testAudit
+	Name     string      `excelLayout:"column:A,required"`
+	Home     testAddress `excelLayout:"offset:B"`
+	Work     testAddress `excelLayout:"offset:D"`
+	Shipping struct {
+		Zip string `excelLayout:"header:Zip"`
+	} `excelLayout:"prefix:Shipping"`
+}
+
+func TestLayoutFields(t *testing.T) {
+	expected := []layoutField{
+		{Index: []int{1, 0}, Name: "Email", Tags: fieldTags{Header: "Email", Email: true}},
+		{Index: []int{2, 0}, Name: "CreatedBy", Tags: fieldTags{Column: "Z"}},
+		{Index: []int{3}, Name: "Name", Tags: fieldTags{Column: "A", Required: true}},
+		{Index: []int{4, 0}, Name: "Street", Tags: fieldTags{Column: "B", Required: true}},
+		{Index: []int{4, 1}, Name: "City", Tags: fieldTags{Column: "C", Required: true}},
+		{Index: []int{5, 0}, Name: "Street", Tags: fieldTags{Column: "D", Required: true}},
+		{Index: []int{5, 1}, Name: "City", Tags: fieldTags{Column: "E", Required: true}},
+		{Index: []int{6, 0}, Name: "ShippingZip", Tags: fieldTags{Header: "ShippingZip"}},
+	}
+
+	fields := layoutFields(reflect.TypeOf(testCustomerRow{}))
+	if len(fields) != len(expected) {
+		t.Fatalf("Expected %d fields, Recived: %d", len(expected), len(fields))
+	}
+	for i, f := range fields {
+		if !reflect.DeepEqual(f, expected[i]) {
+			t.Errorf("Test %d: Expected %+v, Recived: %+v", i, expected[i], f)
+		}
+	}
+}
+
+func TestNestedStructCellsParser(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Name", "Home Street", "Home City", "Work Street", "Work City", "Email", "Shipping Zip"},
+		{"Artziel", "Av. Juárez 1", "CDMX", "Reforma 222", "CDMX", "xxx@yyy.com", "06600"},
+		{"Ángel", "Hidalgo 5", "", "", "", "yyy", "01000"},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testCustomerRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 0: Expected ErrValidationFail, Recived: %v", err)
+	}
+
+	row := l.GetRows()[0].(*testCustomerRow)
+	if row.Index != 2 || row.Home.City != "CDMX" || row.Work.Street != "Reforma 222" || row.Email != "xxx@yyy.com" || row.Shipping.Zip != "06600" {
+		t.Errorf("Test 1: Unexpected row values %+v", row)
+	}
+
+	errs := l.GetErrors()
+	if len(errs) != 4 {
+		t.Errorf("Test 2: Expected 4 errors, Recived: %d", len(errs))
+	}
+	for _, e := range errs {
+		if e.RowIndex != 3 {
+			t.Errorf("Test 2: Expected errors on row 3, Recived: %d", e.RowIndex)
+		}
+	}
+}
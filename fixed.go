@@ -0,0 +1,434 @@
+package Layouts
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	texttransform "golang.org/x/text/transform"
+)
+
+var ErrTagInvalidPosValue error = errors.New("expected \"first-last\" value for \"pos\" tag entry")
+var ErrTagMissingStartValue error = errors.New("expected value for \"start\" tag entry")
+var ErrTagMissingLengthValue error = errors.New("expected value for \"length\" tag entry")
+var ErrTagInvalidAlignValue error = errors.New("expected \"left\" or \"right\" value for \"align\" tag entry")
+var ErrTagInvalidPadValue error = errors.New("expected a single character for \"pad\" tag entry")
+var ErrFieldPositionMissing error = errors.New("a \"pos\" or \"start\" and \"length\" tag entries are required on fixed width layouts")
+var ErrFieldPositionOverlap error = errors.New("the positions of the fixed width fields overlap")
+var ErrLengthOverflowRuleFail error = errors.New("value exceeds the field length")
+
+/**
+ * Maximum length of a fixed width line
+ */
+const fixedMaxLineLength = 1024 * 1024
+
+/**
+ * Layout of a text file where every field takes a fixed number of
+ * characters on the line, like the bank statements and payroll files
+ */
+type FixedWidthLayout struct {
+	Layout
+	encoding   encoding.Encoding
+	lineEnding string
+}
+
+/**
+ * Set the text encoding of the file, like charmap.Windows1252, by default
+ * the files are read and written as UTF-8
+ */
+func (l *FixedWidthLayout) Encoding(e encoding.Encoding) {
+	l.encoding = e
+}
+
+/**
+ * Set the line ending of the written files, by default "\r\n"
+ */
+func (l *FixedWidthLayout) LineEnding(ending string) {
+	l.lineEnding = ending
+}
+
+/**
+ * Parse a position like "1-10" as start and length
+ */
+func parsePosition(val string) (int64, int64, error) {
+	bounds := strings.SplitN(val, "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, ErrTagInvalidPosValue
+	}
+	first, err := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 32)
+	if err != nil || first < 1 {
+		return 0, 0, ErrTagInvalidPosValue
+	}
+	last, err := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 32)
+	if err != nil || last < first {
+		return 0, 0, ErrTagInvalidPosValue
+	}
+	return first, last - first + 1, nil
+}
+
+/**
+ * Return the fields of a fixed width row type sorted by position, every
+ * field needs a position and the positions can not overlap
+ */
+func fixedFields(t reflect.Type) ([]layoutField, error) {
+	fields := layoutFields(t)
+	for _, field := range fields {
+		if field.Tags.Start < 1 || field.Tags.Length < 1 {
+			return nil, ErrFieldPositionMissing
+		}
+	}
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Tags.Start < fields[j].Tags.Start
+	})
+	for i := 1; i < len(fields); i++ {
+		prev := fields[i-1].Tags
+		if prev.Start+prev.Length > fields[i].Tags.Start {
+			return nil, ErrFieldPositionOverlap
+		}
+	}
+	return fields, nil
+}
+
+/**
+ * Return true when the field value is aligned to the right, by default the
+ * numbers are aligned to the right and the rest to the left
+ */
+func alignRight(t reflect.Type, tags fieldTags) bool {
+	if tags.Align != "" {
+		return tags.Align == "right"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return t == decimalType
+}
+
+/**
+ * Return the padding character of the field, by default a space
+ */
+func (ft *fieldTags) padding() string {
+	if ft.Pad == "" {
+		return " "
+	}
+	return ft.Pad
+}
+
+/**
+ * Return the field value of the line with the padding removed, a value made
+ * only of padding characters keeps one of them so "0000" is read as "0". The
+ * sign of a right aligned value goes before the padding, like "-000001.50"
+ */
+func fixedValue(line []rune, t reflect.Type, tags fieldTags) string {
+	start := int(tags.Start) - 1
+	if start >= len(line) {
+		return ""
+	}
+	end := start + int(tags.Length)
+	if end > len(line) {
+		end = len(line)
+	}
+	value := string(line[start:end])
+
+	pad := tags.padding()
+	if !alignRight(t, tags) {
+		return strings.TrimRight(value, pad)
+	}
+	sign := ""
+	if signed := strings.TrimLeft(value, " "); pad != " " && (strings.HasPrefix(signed, "-") || strings.HasPrefix(signed, "+")) {
+		sign, value = signed[:1], signed[1:]
+	}
+	trimmed := strings.TrimLeft(value, pad)
+	if trimmed == "" && strings.TrimSpace(value) != "" {
+		return sign + pad
+	}
+	return sign + trimmed
+}
+
+/**
+ * Return the value padded to the field length, false when it does not fit.
+ * The padding of a right aligned value goes after its sign
+ */
+func padValue(value string, t reflect.Type, tags fieldTags) (string, bool) {
+	missing := int(tags.Length) - utf8.RuneCountInString(value)
+	if missing < 0 {
+		return value, false
+	}
+	padding := strings.Repeat(tags.padding(), missing)
+	if !alignRight(t, tags) {
+		return value + padding, true
+	}
+	if tags.padding() != " " && (strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+")) {
+		return value[:1] + padding + value[1:], true
+	}
+	return padding + value, true
+}
+
+/**
+ * Return the first line of data, by default the line after the header line
+ * when there is one, otherwise the first line of the file
+ */
+func (l *FixedWidthLayout) getFirstDataLine() int {
+	if l.firstDataRow > 0 {
+		return l.firstDataRow
+	}
+	if l.hasHeaderRow && l.headerRow > 0 {
+		return l.headerRow + 1
+	}
+	return 1
+}
+
+/**
+ * Parse the line fields into the row, the errors Column is the field name
+ */
+func (l *FixedWidthLayout) ParseLine(r interface{}, line string) []Error {
+	s := reflect.ValueOf(r).Elem()
+	fields, err := fixedFields(s.Type())
+	if err != nil {
+		return []Error{{RowIndex: getRowIndex(s), Error: err}}
+	}
+	return l.parseLine(s, fields, getRowIndex(s), []rune(line))
+}
+
+func (l *FixedWidthLayout) parseLine(s reflect.Value, fields []layoutField, rowIndex int, line []rune) []Error {
+	errors := []Error{}
+	for _, field := range fields {
+		f := s.FieldByIndex(field.Index)
+		tags := field.Tags
+		if tags.Locale == "" {
+			tags.Locale = l.locale
+		}
+		value := fixedValue(line, f.Type(), tags)
+		errs := parseValue(f, value, tags)
+		for _, e := range errs {
+			errors = append(errors, newError(rowIndex, field.Name, e))
+		}
+		if tags.Unique && len(errs) == 0 && !l.isUniqueValue(field.Name, rowIndex, f, value) {
+			errors = append(errors, Error{RowIndex: rowIndex, Error: ErrNotUnique, Column: field.Name})
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+/**
+ * Return the line of the row values padded to the field lengths, the values
+ * are checked with the field rules
+ */
+func (l *FixedWidthLayout) FormatLine(r interface{}) (string, []Error) {
+	s := reflect.Indirect(reflect.ValueOf(r))
+	fields, err := fixedFields(s.Type())
+	if err != nil {
+		return "", []Error{{RowIndex: getRowIndex(s), Error: err}}
+	}
+	return l.formatLine(s, fields, getRowIndex(s))
+}
+
+func (l *FixedWidthLayout) formatLine(s reflect.Value, fields []layoutField, rowIndex int) (string, []Error) {
+	line := strings.Builder{}
+	length := 0
+	errors := []Error{}
+	for _, field := range fields {
+		f := s.FieldByIndex(field.Index)
+		tags := field.Tags
+		tags.Locale = ""
+		value := formatValue(f, tags)
+		for _, e := range parseValue(reflect.New(f.Type()).Elem(), value, tags) {
+			errors = append(errors, newError(rowIndex, field.Name, e))
+		}
+		value, fits := padValue(value, f.Type(), tags)
+		if !fits {
+			errors = append(errors, Error{RowIndex: rowIndex, Column: field.Name, Error: ErrLengthOverflowRuleFail})
+			continue
+		}
+		for ; length < int(tags.Start)-1; length++ {
+			line.WriteString(" ")
+		}
+		line.WriteString(value)
+		length += int(tags.Length)
+	}
+
+	if len(errors) > 0 {
+		return "", errors
+	}
+	return line.String(), nil
+}
+
+/**
+ * Read the lines of a fixed width file
+ */
+func (l *FixedWidthLayout) ReadFile(rowType interface{}, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return l.Read(rowType, file)
+}
+
+/**
+ * Read the lines of a fixed width text, every data line is parsed into a new
+ * row of the row type
+ */
+func (l *FixedWidthLayout) Read(rowType interface{}, r io.Reader) error {
+	elType := reflect.TypeOf(rowType)
+	fields, err := fixedFields(elType)
+	if err != nil {
+		return err
+	}
+	if l.encoding != nil {
+		r = texttransform.NewReader(r, l.encoding.NewDecoder())
+	}
+
+	hasErrors := false
+	stopped := false
+	elSlice := []interface{}{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), fixedMaxLineLength)
+	firstDataLine := l.getFirstDataLine()
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if lineNumber == 1 {
+			text = strings.TrimPrefix(text, "\uFEFF")
+		}
+		if lineNumber < firstDataLine {
+			continue
+		}
+		if l.isAfterData(lineNumber, []string{text}) {
+			break
+		}
+		if l.isSkippedRow([]string{text}) {
+			continue
+		}
+		if l.isErrorsLimitReached(len(l.errors)) {
+			stopped = true
+			break
+		}
+
+		elItem := reflect.New(elType)
+		setRowIndex(elItem, lineNumber)
+		if errs := l.parseLine(elItem.Elem(), fields, lineNumber, []rune(text)); len(errs) > 0 {
+			hasErrors = true
+			stopped = !l.appendErrors(errs)
+		}
+		elSlice = append(elSlice, elItem.Interface())
+		if stopped {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	l.rows = elSlice
+	if stopped {
+		return ErrValidationTruncated
+	}
+	if hasErrors {
+		return ErrValidationFail
+	}
+	return nil
+}
+
+/**
+ * Write the rows as a fixed width file, nothing is written when a row value
+ * fails the field rules
+ */
+func (l *FixedWidthLayout) WriteFile(rows interface{}, filePath string) error {
+	lines, err := l.formatLines(rows)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	if err := l.writeLines(file, lines); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+/**
+ * Write the rows as fixed width lines, nothing is written when a row value
+ * fails the field rules
+ */
+func (l *FixedWidthLayout) Write(w io.Writer, rows interface{}) error {
+	lines, err := l.formatLines(rows)
+	if err != nil {
+		return err
+	}
+	return l.writeLines(w, lines)
+}
+
+/**
+ * Return the lines of a slice of rows, the errors RowIndex is the line number
+ */
+func (l *FixedWidthLayout) formatLines(rows interface{}) ([]string, error) {
+	values, err := rowValues(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := []string{}
+	hasErrors := false
+	var fields []layoutField
+	for i, row := range values {
+		if fields == nil {
+			if fields, err = fixedFields(row.Type()); err != nil {
+				return nil, err
+			}
+		}
+
+		line, errs := l.formatLine(row, fields, i+1)
+		if len(errs) > 0 {
+			hasErrors = true
+			if !l.appendErrors(errs) {
+				return nil, ErrValidationTruncated
+			}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if hasErrors {
+		return nil, ErrValidationFail
+	}
+	return lines, nil
+}
+
+func (l *FixedWidthLayout) writeLines(w io.Writer, lines []string) error {
+	ending := l.lineEnding
+	if ending == "" {
+		ending = "\r\n"
+	}
+	var encoder *texttransform.Writer
+	if l.encoding != nil {
+		encoder = texttransform.NewWriter(w, l.encoding.NewEncoder())
+		w = encoder
+	}
+	buffer := bufio.NewWriter(w)
+	for _, line := range lines {
+		if _, err := buffer.WriteString(line + ending); err != nil {
+			return err
+		}
+	}
+	if err := buffer.Flush(); err != nil {
+		return err
+	}
+	if encoder != nil {
+		return encoder.Close()
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package Layouts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestFixedWidthTags(t *testing.T) {
+
+	tests := []struct {
+		input       string
+		start       int64
+		length      int64
+		errExpected error
+	}{
+		{`excelLayout:"pos:1-10"`, 1, 10, nil},
+		{`excelLayout:"pos: 11 - 11"`, 11, 1, nil},
+		{`excelLayout:"start:5,length:3,align:right,pad:0"`, 5, 3, nil},
+		{`excelLayout:"pos:0-3"`, 0, 0, ErrTagInvalidPosValue},
+		{`excelLayout:"pos:5-3"`, 0, 0, ErrTagInvalidPosValue},
+		{`excelLayout:"pos:5"`, 0, 0, ErrTagInvalidPosValue},
+		{`excelLayout:"start:5"`, 0, 0, ErrTagMissingLengthValue},
+		{`excelLayout:"length:5"`, 0, 0, ErrTagMissingStartValue},
+		{`excelLayout:"start:0,length:5"`, 0, 0, ErrTagMissingStartValue},
+		{`excelLayout:"pos:1-5,align:center"`, 0, 0, ErrTagInvalidAlignValue},
+		{`excelLayout:"pos:1-5,pad:00"`, 0, 0, ErrTagInvalidPadValue},
+	}
+
+	for i, test := range tests {
+		tags, err := parseOptions(test.input)
+		if err != test.errExpected {
+			t.Errorf("Test %d: Expected error %v, Recived: %v", i, test.errExpected, err)
+			continue
+		}
+		if err == nil && (tags.Start != test.start || tags.Length != test.length) {
+			t.Errorf("Test %d: Expected %d/%d, Recived: %d/%d", i, test.start, test.length, tags.Start, tags.Length)
+		}
+	}
+}
+
+func TestFixedValue(t *testing.T) {
+	stringType := reflect.TypeOf("")
+	intType := reflect.TypeOf(int64(0))
+
+	tests := []struct {
+		line     string
+		t        reflect.Type
+		tags     string
+		expected string
+	}{
+		{"ABC       X", stringType, `excelLayout:"pos:1-10"`, "ABC"},
+		{"  ABC     X", stringType, `excelLayout:"pos:1-10"`, "  ABC"},
+		{"0000012345", intType, `excelLayout:"pos:1-10,pad:0"`, "12345"},
+		{"0000000000", intType, `excelLayout:"pos:1-10,pad:0"`, "0"},
+		{"          ", intType, `excelLayout:"pos:1-10,pad:0"`, "          "},
+		{"-000001.50", intType, `excelLayout:"pos:1-10,pad:0"`, "-1.50"},
+		{"-000000000", intType, `excelLayout:"pos:1-10,pad:0"`, "-0"},
+		{"     12345", intType, `excelLayout:"pos:1-10"`, "12345"},
+		{"AÑO**", stringType, `excelLayout:"pos:2-5,pad:*"`, "ÑO"},
+		{"ABC", stringType, `excelLayout:"pos:2-10"`, "BC"},
+		{"ABC", stringType, `excelLayout:"pos:5-10"`, ""},
+	}
+
+	for i, test := range tests {
+		tags, _ := parseOptions(test.tags)
+		if result := fixedValue([]rune(test.line), test.t, tags); result != test.expected {
+			t.Errorf("Test %d: Expected %q, Recived: %q", i, test.expected, result)
+		}
+	}
+}
+
+type testPayrollRow struct {
+	Row
+	Type   string    `excelLayout:"pos:1-1,enum:E"`
+	Nss    string    `excelLayout:"pos:2-12,required,nss"`
+	Name   string    `excelLayout:"start:13,length:20,required,upper"`
+	Date   time.Time `excelLayout:"pos:33-40,format:20060102"`
+	Days   int64     `excelLayout:"pos:41-42,pad:0,min:0,max:31"`
+	Amount Decimal   `excelLayout:"pos:43-52,pad:0,scale:2"`
+}
+
+const testPayrollFile = "ENCABEZADO NOMINA 2022\r\n" +
+	"E12345678903PEÑA LÓPEZ JOSÉ     20220601150123456.78\r\n" +
+	"E22345678902MARTÍNEZ ANA        20220615310000100.00\r\n" +
+	"TOTAL\r\n"
+
+func TestFixedWidthRead(t *testing.T) {
+	encoded, _ := charmap.Windows1252.NewEncoder().String(testPayrollFile)
+	fileName := filepath.Join(t.TempDir(), "nomina.txt")
+	if err := os.WriteFile(fileName, []byte(encoded), 0644); err != nil {
+		t.Fatalf("Unable to create test file: %s", err.Error())
+	}
+
+	l := FixedWidthLayout{}
+	l.HeaderRow(1)
+	l.FooterMarker("TOTAL")
+	l.Encoding(charmap.Windows1252)
+	if err := l.ReadFile(testPayrollRow{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	rows := l.GetRows()
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, Recived: %d", len(rows))
+	}
+	row := rows[0].(*testPayrollRow)
+	if row.Index != 2 || row.Nss != "12345678903" || row.Name != "PEÑA LÓPEZ JOSÉ" || row.Days != 15 ||
+		row.Amount.String() != "123456.78" || !row.Date.Equal(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected row values %+v", row)
+	}
+
+	l = FixedWidthLayout{}
+	l.HeaderRow(1)
+	l.LastDataRow(3)
+	err := l.Read(testPayrollRow{}, strings.NewReader(strings.Replace(testPayrollFile, "310000100.00", "320000100.00", 1)))
+	if err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expected := []Error{{RowIndex: 3, Column: "Days", Error: ErrMaxValueRuleFail}}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+func TestFixedWidthWrite(t *testing.T) {
+	amounts := []Decimal{}
+	for _, v := range []string{"123456.78", "100.00"} {
+		amount, _ := ParseDecimal(v)
+		amounts = append(amounts, amount)
+	}
+	rows := []interface{}{
+		&testPayrollRow{Type: "E", Nss: "12345678903", Name: "PEÑA LÓPEZ JOSÉ", Date: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), Days: 15, Amount: amounts[0]},
+		testPayrollRow{Type: "E", Nss: "22345678902", Name: "MARTÍNEZ ANA", Date: time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC), Days: 31, Amount: amounts[1]},
+	}
+
+	l := FixedWidthLayout{}
+	l.LineEnding("\n")
+	buffer := &bytes.Buffer{}
+	if err := l.Write(buffer, rows); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	expected := "E12345678903PEÑA LÓPEZ JOSÉ     20220601150123456.78\n" +
+		"E22345678902MARTÍNEZ ANA        20220615310000100.00\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected %q, Recived: %q", expected, buffer.String())
+	}
+
+	l = FixedWidthLayout{}
+	l.Encoding(charmap.Windows1252)
+	fileName := filepath.Join(t.TempDir(), "nomina.txt")
+	if err := l.WriteFile(rows, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	l = FixedWidthLayout{}
+	l.Encoding(charmap.Windows1252)
+	if err := l.ReadFile(testPayrollRow{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	if row := l.GetRows()[1].(*testPayrollRow); row.Name != "MARTÍNEZ ANA" || row.Amount.String() != "100.00" {
+		t.Errorf("Unexpected row values %+v", row)
+	}
+
+	rows[1] = testPayrollRow{Type: "X", Nss: "22345678902", Name: "MARTÍNEZ ANA HERNÁNDEZ DE LA O", Days: 31}
+	l = FixedWidthLayout{}
+	buffer.Reset()
+	if err := l.Write(buffer, rows); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expectedErrors := []Error{
+		{RowIndex: 2, Column: "Type", Error: ErrEnumRuleFail},
+		{RowIndex: 2, Column: "Name", Error: ErrLengthOverflowRuleFail},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expectedErrors) {
+		t.Errorf("Expected %v, Recived: %v", expectedErrors, errs)
+	}
+	if buffer.Len() > 0 {
+		t.Errorf("Expected no output, Recived: %q", buffer.String())
+	}
+}
+
+type testBalanceRow struct {
+	Row
+	Account string  `excelLayout:"pos:1-4"`
+	Balance Decimal `excelLayout:"pos:5-14,pad:0,scale:2"`
+	Days    int64   `excelLayout:"pos:15-20,pad:0"`
+}
+
+func TestFixedWidthNegativeValues(t *testing.T) {
+	balance, _ := ParseDecimal("-1.50")
+	rows := []testBalanceRow{{Account: "A1", Balance: balance, Days: -12}}
+
+	l := FixedWidthLayout{}
+	l.LineEnding("\n")
+	buffer := &bytes.Buffer{}
+	if err := l.Write(buffer, rows); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	expected := "A1  -000001.50-00012\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected %q, Recived: %q", expected, buffer.String())
+	}
+
+	l = FixedWidthLayout{}
+	if err := l.Read(testBalanceRow{}, buffer); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	if row := l.GetRows()[0].(*testBalanceRow); row.Balance.String() != "-1.50" || row.Days != -12 {
+		t.Errorf("Unexpected row values %+v", row)
+	}
+}
+
+type testUniqueCodeRow struct {
+	Row
+	Code string `excelLayout:"pos:1-3,unique"`
+}
+
+func TestFixedWidthUnique(t *testing.T) {
+	l := FixedWidthLayout{}
+	if err := l.Read(testUniqueCodeRow{}, strings.NewReader("AAA\nBBB\n\nCCC\n\nAAA\n")); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expected := []Error{{RowIndex: 6, Column: "Code", Error: ErrNotUnique}}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+type testOverlapRow struct {
+	Code string `excelLayout:"pos:1-5"`
+	Name string `excelLayout:"pos:5-10"`
+}
+
+type testMissingPosRow struct {
+	Code string `excelLayout:"pos:1-5"`
+	Name string `excelLayout:"required"`
+}
+
+func TestFixedWidthFieldsErrors(t *testing.T) {
+
+	tests := []struct {
+		rowType     interface{}
+		errExpected error
+	}{
+		{testOverlapRow{}, ErrFieldPositionOverlap},
+		{testMissingPosRow{}, ErrFieldPositionMissing},
+		{[]string{"A"}, ErrRowsNotSlice},
+	}
+
+	for i, test := range tests {
+		l := FixedWidthLayout{}
+		if i < 2 {
+			if err := l.Read(test.rowType, strings.NewReader("")); err != test.errExpected {
+				t.Errorf("Test %d: Expected %v, Recived: %v", i, test.errExpected, err)
+			}
+			continue
+		}
+		if err := l.Write(&bytes.Buffer{}, test.rowType); err != test.errExpected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.errExpected, err)
+		}
+	}
+}
@@ -2,15 +2,18 @@ module gitlab.com/artziel/go-layouts
 
 go 1.17
 
-require github.com/xuri/excelize/v2 v2.6.0
+require (
+	github.com/richardlehane/mscfb v1.0.4
+	github.com/xuri/excelize/v2 v2.6.0
+	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
-	github.com/richardlehane/mscfb v1.0.4 // indirect
 	github.com/richardlehane/msoleps v1.0.3 // indirect
 	github.com/xuri/efp v0.0.0-20220407160117-ad0f7a785be8 // indirect
 	github.com/xuri/nfp v0.0.0-20220409054826-5e722a1d9e22 // indirect
 	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e // indirect
 	golang.org/x/net v0.0.0-20220526153639-5463443f8c37 // indirect
-	golang.org/x/text v0.3.7 // indirect
 )
@@ -0,0 +1,515 @@
+package Layouts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrJSONInvalid error = errors.New("invalid JSON document, expected an array or newline delimited objects")
+var ErrJSONRowInvalid error = errors.New("invalid JSON object")
+
+/**
+ * Layout of JSON arrays and newline delimited JSON (NDJSON) streams, the
+ * row fields are mapped by object key instead of column
+ */
+type JSONLayout struct {
+	Layout
+	ndjson bool
+}
+
+/**
+ * Write the rows as newline delimited JSON instead of a JSON array, the
+ * format of the read documents is detected
+ */
+func (l *JSONLayout) NDJSON() {
+	l.ndjson = true
+}
+
+/**
+ * Row field with its object key, the key is the "json" tag name, the
+ * "header" tag entry or the field name
+ */
+type jsonField struct {
+	layoutField
+	Key string
+}
+
+/**
+ * Return the fields of a JSON row type, the fields with json:"-" are ignored
+ */
+func jsonFields(t reflect.Type) []jsonField {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	fields := []jsonField{}
+	for _, field := range layoutFields(t) {
+		key := strings.Split(t.FieldByIndex(field.Index).Tag.Get("json"), ",")[0]
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = field.Tags.Header
+		}
+		if key == "" {
+			key = field.Name
+		}
+		fields = append(fields, jsonField{layoutField: field, Key: key})
+	}
+	return fields
+}
+
+/**
+ * Return the object value of a key, the keys are compared ignoring case and
+ * spaces when there is no exact match
+ */
+func lookupKey(object map[string]json.RawMessage, key string) (json.RawMessage, bool) {
+	if raw, found := object[key]; found {
+		return raw, true
+	}
+	for k, raw := range object {
+		if headerKey(k) == headerKey(key) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+/**
+ * Return the text of a scalar JSON value as read from a cell, false for
+ * arrays and objects
+ */
+func jsonText(raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 {
+		return "", true
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+/**
+ * Check if the JSON value is a string, the numbers are read without the
+ * locale separators
+ */
+func isJSONString(raw json.RawMessage) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(raw), []byte(`"`))
+}
+
+/**
+ * Return the items of a JSON array, false when the value is not an array
+ */
+func jsonArray(raw json.RawMessage) ([]json.RawMessage, bool) {
+	items := []json.RawMessage{}
+	if err := json.Unmarshal(raw, &items); err != nil || items == nil {
+		return nil, false
+	}
+	return items, true
+}
+
+/**
+ * Parse the JSON object into the row, the errors Column is the object key.
+ * Like the missing cells, the missing and null keys are only checked on the
+ * required fields and the fields with a default value. The locale is only
+ * applied to the values written as strings
+ */
+func (l *JSONLayout) ParseObject(r interface{}, data []byte) []Error {
+	s := reflect.ValueOf(r).Elem()
+	return l.parseObject(s, jsonFields(s.Type()), getRowIndex(s), data)
+}
+
+func (l *JSONLayout) parseObject(s reflect.Value, fields []jsonField, rowIndex int, data []byte) []Error {
+	object := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return []Error{{RowIndex: rowIndex, Error: ErrJSONRowInvalid}}
+	}
+
+	errors := []Error{}
+	for _, field := range fields {
+		f := s.FieldByIndex(field.Index)
+		tags := field.Tags
+		if tags.Locale == "" {
+			tags.Locale = l.locale
+		}
+		raw, found := lookupKey(object, field.Key)
+
+		if isGroupType(f.Type()) {
+			errors = append(errors, l.parseGroup(f, field, rowIndex, raw)...)
+			continue
+		}
+		if !found || string(raw) == "null" {
+			if !tags.Required && !tags.hasDefault {
+				continue
+			}
+			raw = nil
+		}
+		if items, isArray := jsonArray(raw); isArray && f.Kind() == reflect.Slice {
+			values := []string{}
+			plain := []bool{}
+			for i, item := range items {
+				value, ok := jsonText(item)
+				if !ok {
+					errors = append(errors, Error{RowIndex: rowIndex, Column: field.Key, Error: ErrTextValueInvalid, Item: i + 1})
+				}
+				values = append(values, value)
+				plain = append(plain, !isJSONString(item))
+			}
+			if tags.Required && len(values) == 0 {
+				errors = append(errors, Error{RowIndex: rowIndex, Column: field.Key, Error: ErrRequiredValueRuleFail})
+			}
+			for _, e := range parseLocaleItems(f, values, tags, plain) {
+				errors = append(errors, newError(rowIndex, field.Key, e))
+			}
+			continue
+		}
+
+		value, ok := jsonText(raw)
+		if !ok {
+			errors = append(errors, Error{RowIndex: rowIndex, Column: field.Key, Error: ErrTextValueInvalid})
+			continue
+		}
+		if !isJSONString(raw) {
+			tags.Locale = ""
+		}
+		errs := parseValue(f, value, tags)
+		for _, e := range errs {
+			errors = append(errors, newError(rowIndex, field.Key, e))
+		}
+		if tags.Unique && len(errs) == 0 && !l.isUniqueValue(field.Key, rowIndex, f, value) {
+			errors = append(errors, Error{RowIndex: rowIndex, Error: ErrNotUnique, Column: field.Key})
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+/**
+ * Parse an array of objects into a slice of structs, like the detail and
+ * repeating group fields, the errors keep the item position
+ */
+func (l *JSONLayout) parseGroup(f reflect.Value, field jsonField, rowIndex int, raw json.RawMessage) []Error {
+	items, isArray := jsonArray(raw)
+	if !isArray {
+		if text, ok := jsonText(raw); !ok || text != "" {
+			return []Error{{RowIndex: rowIndex, Column: field.Key, Error: ErrTextValueInvalid}}
+		}
+	}
+
+	errors := []Error{}
+	childFields := jsonFields(f.Type().Elem())
+	for i, data := range items {
+		item := reflect.New(f.Type().Elem())
+		setRowIndex(item, rowIndex)
+		errs := l.parseObject(item.Elem(), childFields, rowIndex, data)
+		for j := range errs {
+			errs[j].Item = i + 1
+		}
+		if len(errs) > 0 {
+			errors = append(errors, errs...)
+			continue
+		}
+		f.Set(reflect.Append(f, item.Elem()))
+	}
+	for _, e := range detailCountErrors(field.layoutField, rowIndex, len(items)) {
+		e.Column = field.Key
+		errors = append(errors, e)
+	}
+	return errors
+}
+
+/**
+ * Read a JSON array or NDJSON file
+ */
+func (l *JSONLayout) ReadFile(rowType interface{}, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return l.Read(rowType, file)
+}
+
+/**
+ * Read a JSON array or a NDJSON stream, every object is parsed into a new
+ * row of the row type. The RowIndex is the array position starting at 1 or
+ * the line number on NDJSON streams
+ */
+func (l *JSONLayout) Read(rowType interface{}, r io.Reader) error {
+	elType := reflect.TypeOf(rowType)
+	fields := jsonFields(elType)
+
+	hasErrors := false
+	stopped := false
+	elSlice := []interface{}{}
+	parseRow := func(rowIndex int, data []byte) bool {
+		if l.isErrorsLimitReached(len(l.errors)) {
+			stopped = true
+			return false
+		}
+		elItem := reflect.New(elType)
+		setRowIndex(elItem, rowIndex)
+		if errs := l.parseObject(elItem.Elem(), fields, rowIndex, data); len(errs) > 0 {
+			hasErrors = true
+			stopped = !l.appendErrors(errs)
+		}
+		elSlice = append(elSlice, elItem.Interface())
+		return !stopped
+	}
+
+	reader := bufio.NewReader(r)
+	first, err := firstNonSpace(reader)
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	if first == '[' {
+		decoder := json.NewDecoder(reader)
+		if _, err := decoder.Token(); err != nil {
+			return ErrJSONInvalid
+		}
+		for rowIndex := 1; decoder.More(); rowIndex++ {
+			data := json.RawMessage{}
+			if err := decoder.Decode(&data); err != nil {
+				return ErrJSONInvalid
+			}
+			if !parseRow(rowIndex, data) {
+				break
+			}
+		}
+		if !stopped {
+			if _, err := decoder.Token(); err != nil {
+				return ErrJSONInvalid
+			}
+		}
+	} else {
+		scanner := bufio.NewScanner(reader)
+		scanner.Buffer(make([]byte, 64*1024), fixedMaxLineLength)
+		for lineNumber := 1; scanner.Scan(); lineNumber++ {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			if !parseRow(lineNumber, append([]byte{}, line...)) {
+				break
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	l.rows = elSlice
+	if stopped {
+		return ErrValidationTruncated
+	}
+	if hasErrors {
+		return ErrValidationFail
+	}
+	return nil
+}
+
+/**
+ * Return the first character that is not a space or byte order mark,
+ * without consuming it
+ */
+func firstNonSpace(reader *bufio.Reader) (rune, error) {
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		if r == '\uFEFF' || r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			continue
+		}
+		return r, reader.UnreadRune()
+	}
+}
+
+/**
+ * Check the row values with the field rules, the slices of structs are
+ * checked by item
+ */
+func (l *JSONLayout) checkFields(s reflect.Value, fields []jsonField, rowIndex int) []Error {
+	errors := []Error{}
+	for _, field := range fields {
+		f := s.FieldByIndex(field.Index)
+		tags := field.Tags
+		tags.Locale = ""
+
+		if isGroupType(f.Type()) {
+			childFields := jsonFields(f.Type().Elem())
+			for i := 0; i < f.Len(); i++ {
+				for _, e := range l.checkFields(f.Index(i), childFields, rowIndex) {
+					e.Item = i + 1
+					errors = append(errors, e)
+				}
+			}
+			for _, e := range detailCountErrors(field.layoutField, rowIndex, f.Len()) {
+				e.Column = field.Key
+				errors = append(errors, e)
+			}
+			continue
+		}
+		var errs []error
+		if f.Kind() == reflect.Slice {
+			items := []string{}
+			for i := 0; i < f.Len(); i++ {
+				items = append(items, formatValue(f.Index(i), tags))
+			}
+			if tags.Required && len(items) == 0 {
+				errs = append(errs, ErrRequiredValueRuleFail)
+			}
+			errs = append(errs, parseItems(reflect.New(f.Type()).Elem(), items, tags)...)
+		} else {
+			errs = parseValue(reflect.New(f.Type()).Elem(), formatValue(f, tags), tags)
+		}
+		for _, e := range errs {
+			errors = append(errors, newError(rowIndex, field.Key, e))
+		}
+	}
+	return errors
+}
+
+/**
+ * Return the JSON encoding of a field value, the dates are written with the
+ * field format and the decimals as numbers
+ */
+func jsonValue(f reflect.Value, tags fieldTags) ([]byte, error) {
+	switch {
+	case f.Type() == timeType:
+		return json.Marshal(formatDate(f.Interface().(time.Time), tags))
+	case f.Type() == decimalType:
+		return []byte(f.Interface().(Decimal).String()), nil
+	case isGroupType(f.Type()):
+		fields := jsonFields(f.Type().Elem())
+		items := [][]byte{}
+		for i := 0; i < f.Len(); i++ {
+			item, err := jsonObject(f.Index(i), fields)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return append(append([]byte("["), bytes.Join(items, []byte(","))...), ']'), nil
+	case f.Kind() == reflect.Slice:
+		items := [][]byte{}
+		for i := 0; i < f.Len(); i++ {
+			item, err := jsonValue(f.Index(i), tags)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return append(append([]byte("["), bytes.Join(items, []byte(","))...), ']'), nil
+	}
+	return json.Marshal(f.Interface())
+}
+
+/**
+ * Return the JSON object of a row, the keys are written in fields order
+ */
+func jsonObject(s reflect.Value, fields []jsonField) ([]byte, error) {
+	object := bytes.Buffer{}
+	object.WriteString("{")
+	for i, field := range fields {
+		key, _ := json.Marshal(field.Key)
+		value, err := jsonValue(s.FieldByIndex(field.Index), field.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			object.WriteString(",")
+		}
+		object.Write(key)
+		object.WriteString(":")
+		object.Write(value)
+	}
+	object.WriteString("}")
+	return object.Bytes(), nil
+}
+
+/**
+ * Write the rows as a JSON file, nothing is written when a row value fails
+ * the field rules
+ */
+func (l *JSONLayout) WriteFile(rows interface{}, filePath string) error {
+	content := bytes.Buffer{}
+	if err := l.Write(&content, rows); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, content.Bytes(), 0644)
+}
+
+/**
+ * Write the rows as a JSON array, one object by line, or as NDJSON,
+ * nothing is written when a row value fails the field rules. The errors
+ * RowIndex is the row position starting at 1
+ */
+func (l *JSONLayout) Write(w io.Writer, rows interface{}) error {
+	values, err := rowValues(rows)
+	if err != nil {
+		return err
+	}
+
+	objects := [][]byte{}
+	hasErrors := false
+	var fields []jsonField
+	for i, row := range values {
+		if fields == nil {
+			fields = jsonFields(row.Type())
+		}
+		if errs := l.checkFields(row, fields, i+1); len(errs) > 0 {
+			hasErrors = true
+			if !l.appendErrors(errs) {
+				return ErrValidationTruncated
+			}
+			continue
+		}
+		object, err := jsonObject(row, fields)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+	if hasErrors {
+		return ErrValidationFail
+	}
+
+	content := bytes.Buffer{}
+	if l.ndjson {
+		for _, object := range objects {
+			content.Write(object)
+			content.WriteString("\n")
+		}
+	} else {
+		content.WriteString("[\n")
+		content.Write(bytes.Join(objects, []byte(",\n")))
+		content.WriteString("\n]\n")
+	}
+	_, err = w.Write(content.Bytes())
+	return err
+}
@@ -0,0 +1,270 @@
+package Layouts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testApiLine struct {
+	Sku      string `json:"sku" excelLayout:"required"`
+	Quantity int64  `json:"qty" excelLayout:"min:1"`
+}
+
+type testApiOrder struct {
+	Row
+	Folio  string        `json:"folio" excelLayout:"required"`
+	Email  string        `excelLayout:"header:Correo,email"`
+	Date   time.Time     `json:"date" excelLayout:"format:2006-01-02"`
+	Total  Decimal       `json:"total" excelLayout:"scale:2"`
+	Paid   bool          `json:"paid" excelLayout:"bool"`
+	Tags   []string      `json:"tags" excelLayout:"commaSeparatedValue,enum:A|B|C"`
+	Lines  []testApiLine `json:"lines" excelLayout:"detail,minItems:1"`
+	Notes  string        `json:"-" excelLayout:"required"`
+	Source string
+}
+
+func TestJSONReadArray(t *testing.T) {
+	content := `[
+		{"folio": "F-1", "correo": "ana@example.com", "date": "2022-06-01", "total": 123.45,
+		 "paid": true, "tags": ["A", "C"], "lines": [{"sku": "X1", "qty": 2}]},
+		{"folio": "F-1", "Correo": "ana", "date": "01/06/2022", "total": "1.234",
+		 "paid": "quizá", "tags": ["D", {}], "lines": [{"sku": "", "qty": 0}, {"sku": "X2", "qty": 1}]},
+		{"folio": null, "tags": "A,B", "lines": []},
+		"F-4"
+	]`
+
+	l := JSONLayout{}
+	err := l.Read(testApiOrder{}, strings.NewReader(content))
+	if err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+
+	rows := l.GetRows()
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, Recived: %d", len(rows))
+	}
+	row := rows[0].(*testApiOrder)
+	if row.Index != 1 || row.Folio != "F-1" || row.Email != "ana@example.com" || row.Total.String() != "123.45" ||
+		!row.Paid || !reflect.DeepEqual(row.Tags, []string{"A", "C"}) || len(row.Lines) != 1 || row.Lines[0].Quantity != 2 ||
+		!row.Date.Equal(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected row values %+v", row)
+	}
+	if row := rows[2].(*testApiOrder); !reflect.DeepEqual(row.Tags, []string{"A", "B"}) {
+		t.Errorf("Expected tags [A B], Recived: %v", row.Tags)
+	}
+
+	expected := []Error{
+		{RowIndex: 2, Column: "Correo", Error: ErrEmailValueRuleFail},
+		{RowIndex: 2, Column: "date", Error: ErrDateInvalid},
+		{RowIndex: 2, Column: "total", Error: ErrScaleRuleFail},
+		{RowIndex: 2, Column: "paid", Error: ErrBoolInvalid},
+		{RowIndex: 2, Column: "tags", Error: ErrTextValueInvalid, Item: 2},
+		{RowIndex: 2, Column: "tags", Error: ErrEnumRuleFail, Item: 1},
+		{RowIndex: 2, Column: "sku", Error: ErrRequiredValueRuleFail, Item: 1},
+		{RowIndex: 2, Column: "qty", Error: ErrMinValueRuleFail, Item: 1},
+		{RowIndex: 3, Column: "folio", Error: ErrRequiredValueRuleFail},
+		{RowIndex: 3, Column: "lines", Error: ErrMinItemsRuleFail},
+		{RowIndex: 4, Error: ErrJSONRowInvalid},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+func TestJSONReadNDJSON(t *testing.T) {
+	content := "\uFEFF{\"folio\": \"F-1\", \"lines\": [{\"sku\": \"X1\", \"qty\": 1}]}\r\n" +
+		"\n" +
+		"{\"folio\": \"F-2\", \"lines\": [{\"sku\": \"X2\", \"qty\": 1}]}\n" +
+		"{\"folio\": \"F-3\", \"lines\": [{\"sku\": \"X3\"\n"
+	fileName := filepath.Join(t.TempDir(), "pedidos.ndjson")
+	if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+		t.Fatalf("Unable to create test file: %s", err.Error())
+	}
+
+	l := JSONLayout{}
+	if err := l.ReadFile(testApiOrder{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	rows := l.GetRows()
+	if len(rows) != 3 || rows[1].(*testApiOrder).Index != 3 || rows[1].(*testApiOrder).Folio != "F-2" {
+		t.Fatalf("Unexpected rows %v", rows)
+	}
+	expected := []Error{{RowIndex: 4, Error: ErrJSONRowInvalid}}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+
+	l = JSONLayout{}
+	if err := l.Read(testApiOrder{}, strings.NewReader(`[{"folio": "F-1"}`)); err != ErrJSONInvalid {
+		t.Errorf("Expected %v, Recived: %v", ErrJSONInvalid, err)
+	}
+	l = JSONLayout{}
+	if err := l.Read(testApiOrder{}, strings.NewReader("  ")); err != nil || l.CountRows() != 0 {
+		t.Errorf("Expected no rows, Recived: %v %d", err, l.CountRows())
+	}
+}
+
+func TestJSONWrite(t *testing.T) {
+	total, _ := ParseDecimal("123.40")
+	rows := []interface{}{
+		&testApiOrder{Folio: "F-1", Email: "ana@example.com", Date: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC),
+			Total: total, Paid: true, Tags: []string{"A"}, Lines: []testApiLine{{Sku: "X1", Quantity: 2}}, Notes: "N"},
+		testApiOrder{Folio: "F-2", Lines: []testApiLine{{Sku: "X2", Quantity: 1}}},
+	}
+
+	l := JSONLayout{}
+	buffer := &bytes.Buffer{}
+	if err := l.Write(buffer, rows); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	first := `{"folio":"F-1","Correo":"ana@example.com","date":"2022-06-01","total":123.40,"paid":true,"tags":["A"],"lines":[{"sku":"X1","qty":2}]}`
+	second := `{"folio":"F-2","Correo":"","date":"","total":0,"paid":false,"tags":[],"lines":[{"sku":"X2","qty":1}]}`
+	expected := "[\n" + first + ",\n" + second + "\n]\n"
+	if buffer.String() != expected {
+		t.Errorf("Expected %q, Recived: %q", expected, buffer.String())
+	}
+
+	l = JSONLayout{}
+	l.NDJSON()
+	fileName := filepath.Join(t.TempDir(), "pedidos.ndjson")
+	if err := l.WriteFile(rows, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	content, _ := os.ReadFile(fileName)
+	if string(content) != first+"\n"+second+"\n" {
+		t.Errorf("Expected NDJSON output, Recived: %q", string(content))
+	}
+	l = JSONLayout{}
+	if err := l.ReadFile(testApiOrder{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	if row := l.GetRows()[0].(*testApiOrder); row.Total.String() != "123.40" || row.Lines[0].Sku != "X1" {
+		t.Errorf("Unexpected row values %+v", row)
+	}
+
+	rows[1] = testApiOrder{Tags: []string{"Z"}, Lines: []testApiLine{{Quantity: 1}}}
+	l = JSONLayout{}
+	buffer.Reset()
+	if err := l.Write(buffer, rows); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expectedErrors := []Error{
+		{RowIndex: 2, Column: "folio", Error: ErrRequiredValueRuleFail},
+		{RowIndex: 2, Column: "tags", Error: ErrEnumRuleFail, Item: 1},
+		{RowIndex: 2, Column: "sku", Error: ErrRequiredValueRuleFail, Item: 1},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expectedErrors) {
+		t.Errorf("Expected %v, Recived: %v", expectedErrors, errs)
+	}
+	if buffer.Len() > 0 {
+		t.Errorf("Expected no output, Recived: %q", buffer.String())
+	}
+	if err := l.Write(buffer, "rows"); err != ErrRowsNotSlice {
+		t.Errorf("Expected %v, Recived: %v", ErrRowsNotSlice, err)
+	}
+}
+
+type testApiPayment struct {
+	Row
+	Folio  string    `json:"folio" excelLayout:"required,unique"`
+	Amount float64   `json:"amount" excelLayout:"min:0"`
+	Total  Decimal   `json:"total" excelLayout:"scale:2"`
+	Rates  []float64 `json:"rates" excelLayout:"commaSeparatedValue"`
+}
+
+func TestJSONReadLocaleAndUnique(t *testing.T) {
+	content := `{"folio": "A", "amount": 1.5, "total": "1.234,50", "rates": [0.5, "1,25", 2]}
+{"folio": "B", "amount": "2,5", "total": 10.25, "rates": ["1,25", 0.5]}
+{"folio": "C", "amount": 1500, "total": 1}
+{"folio": "A", "amount": 1, "total": 1}`
+
+	l := JSONLayout{}
+	l.Locale("es")
+	if err := l.Read(testApiPayment{}, strings.NewReader(content)); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	rows := l.GetRows()
+	first, second := rows[0].(*testApiPayment), rows[1].(*testApiPayment)
+	if first.Amount != 1.5 || first.Total.String() != "1234.50" || second.Amount != 2.5 || second.Total.String() != "10.25" ||
+		!reflect.DeepEqual(first.Rates, []float64{0.5, 1.25, 2}) || !reflect.DeepEqual(second.Rates, []float64{1.25, 0.5}) {
+		t.Errorf("Unexpected row values %+v %+v", first, second)
+	}
+	expected := []Error{{RowIndex: 4, Column: "folio", Error: ErrNotUnique}}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+type testUniqueRow struct {
+	Row
+	Code string `json:"code" excelLayout:"header:Code,unique"`
+	Name string `json:"name" excelLayout:"header:Name"`
+}
+
+func TestUniqueSameOnExcelAndJSON(t *testing.T) {
+	codes := []string{"A", "B", "A", "", "", "B", "C"}
+	cells := [][]interface{}{{"Code", "Name"}}
+	content := ""
+	for _, code := range codes {
+		cells = append(cells, []interface{}{code, "x"})
+		content += `{"code": "` + code + `", "name": "x"}` + "\n"
+	}
+
+	excel := ExcelLayout{}
+	if err := excel.ReadFile(testUniqueRow{}, createTestFile(t, cells)); err != ErrValidationFail {
+		t.Fatalf("Test 0: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	api := JSONLayout{}
+	if err := api.Read(testUniqueRow{}, strings.NewReader(content)); err != ErrValidationFail {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+
+	expected := []int{3, 6}
+	excelRows, apiRows := []int{}, []int{}
+	for _, e := range excel.GetErrors() {
+		if e.Error == ErrNotUnique {
+			excelRows = append(excelRows, e.RowIndex-1)
+		}
+	}
+	for _, e := range api.GetErrors() {
+		if e.Error == ErrNotUnique {
+			apiRows = append(apiRows, e.RowIndex)
+		}
+	}
+	if !reflect.DeepEqual(excelRows, expected) || !reflect.DeepEqual(apiRows, expected) {
+		t.Errorf("Expected repeated rows %v, Recived: %v %v", expected, excelRows, apiRows)
+	}
+}
+
+func TestStopOnFirstErrorOnExcelAndJSON(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Folio", "Amount", "Total"},
+		{"A", -1, 1},
+		{"B", 1, 1},
+		{"C", -1, 1},
+	})
+	content := `{"folio": "A", "amount": -1, "total": 1}
+{"folio": "B", "amount": 1, "total": 1}
+{"folio": "C", "amount": -1, "total": 1}`
+
+	excel := ExcelLayout{}
+	excel.StopOnFirstError()
+	if err := excel.ReadFile(testApiPayment{}, fileName); err != ErrValidationTruncated {
+		t.Errorf("Test 0: Expected %v, Recived: %v", ErrValidationTruncated, err)
+	} else if len(excel.GetErrors()) != 1 || excel.CountRows() != 1 || excel.IsTruncated() {
+		t.Errorf("Test 0: Expected 1 error and 1 row, Recived: %v %d", excel.GetErrors(), excel.CountRows())
+	}
+
+	api := JSONLayout{}
+	api.StopOnFirstError()
+	if err := api.Read(testApiPayment{}, strings.NewReader(content)); err != ErrValidationTruncated {
+		t.Errorf("Test 1: Expected %v, Recived: %v", ErrValidationTruncated, err)
+	} else if len(api.GetErrors()) != 1 || api.CountRows() != 1 || api.IsTruncated() {
+		t.Errorf("Test 1: Expected 1 error and 1 row, Recived: %v %d", api.GetErrors(), api.CountRows())
+	}
+}
@@ -1,6 +1,11 @@
 package Layouts
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 /**
  * Row Layout Structure
@@ -16,6 +21,18 @@ type Error struct {
 	RowIndex int
 	Error    error
 	Column   string
+	Item     int
+}
+
+/**
+ * Return the row error of a field rule error, the list item errors keep the
+ * item position
+ */
+func newError(rowIndex int, column string, err error) Error {
+	if e, ok := err.(*itemError); ok {
+		return Error{RowIndex: rowIndex, Column: column, Error: e.Err, Item: e.Item}
+	}
+	return Error{RowIndex: rowIndex, Column: column, Error: err}
 }
 
 /**
@@ -38,14 +55,70 @@ func ErrToMessage(e *Error) string {
 		message = fmt.Sprintf("El valor de la columna \"%s\" no es un correo electrónico válido", e.Column)
 	case ErrRegexRuleFail:
 		message = fmt.Sprintf("El valor de la columna \"%s\" no cumple con la expresión regular", e.Column)
+	case ErrPatternRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no cumple con el formato requerido", e.Column)
+	case ErrRfcRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un RFC válido", e.Column)
+	case ErrCurpRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es una CURP válida", e.Column)
+	case ErrClabeRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es una CLABE interbancaria válida", e.Column)
+	case ErrNssRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un número de seguridad social válido", e.Column)
+	case ErrPhoneRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un número telefónico válido", e.Column)
+	case ErrZipRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un código postal válido", e.Column)
+	case ErrCountryRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un código de país válido", e.Column)
+	case ErrCurrencyRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un código de moneda válido", e.Column)
+	case ErrDomainRuleFail:
+		message = fmt.Sprintf("El dominio del valor de la columna \"%s\" no está permitido", e.Column)
+	case ErrMinItemsRuleFail:
+		message = fmt.Sprintf("La lista de la columna \"%s\" tiene menos elementos de los permitidos", e.Column)
+	case ErrMaxItemsRuleFail:
+		message = fmt.Sprintf("La lista de la columna \"%s\" tiene más elementos de los permitidos", e.Column)
+	case ErrUniqueItemsRuleFail:
+		message = fmt.Sprintf("La lista de la columna \"%s\" tiene elementos repetidos", e.Column)
+	case ErrQuotedItemInvalid:
+		message = fmt.Sprintf("La lista de la columna \"%s\" tiene un elemento entre comillas sin cerrar", e.Column)
+	case ErrRefRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no existe en el catálogo referenciado", e.Column)
+	case ErrRefCatalogNotFound:
+		message = fmt.Sprintf("No se encontró el catálogo referenciado por la columna \"%s\"", e.Column)
+	case ErrLengthOverflowRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" excede la longitud del campo", e.Column)
+	case ErrJSONRowInvalid:
+		message = "El registro no es un objeto JSON válido"
+	case ErrExistsRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no existe en el sistema", e.Column)
 	case ErrRegexInvalid:
 		message = fmt.Sprintf("La expresión regular definida para la columna \"%s\" es inválida", e.Column)
 	case ErrIntegerInvalid:
 		message = fmt.Sprintf("El valor de la columna \"%s\" no es un valor entero válido", e.Column)
+	case ErrDateInvalid:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es una fecha válida", e.Column)
+	case ErrScaleRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" tiene más decimales de los permitidos", e.Column)
+	case ErrPrecisionRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" tiene más dígitos de los permitidos", e.Column)
+	case ErrTextValueInvalid:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es válido", e.Column)
+	case ErrBoolInvalid:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no es un valor booleano válido", e.Column)
+	case ErrFormulaRejected:
+		message = fmt.Sprintf("La columna \"%s\" contiene una fórmula y solo se permiten valores", e.Column)
+	case ErrFormulaInvalid:
+		message = fmt.Sprintf("No se pudo calcular la fórmula de la columna \"%s\"", e.Column)
 	case ErrDecimalInvalid:
 		message = fmt.Sprintf("El valor de la columna \"%s\" no es un valor decimal válido", e.Column)
 	case ErrNotUnique:
 		message = fmt.Sprintf("El valor de la columna \"%s\" debe ser único por archivo", e.Column)
+	case ErrEnumRuleFail:
+		message = fmt.Sprintf("El valor de la columna \"%s\" no está entre los valores permitidos", e.Column)
+	case ErrHeaderNotFound:
+		message = fmt.Sprintf("No se encontró la columna con encabezado \"%s\"", e.Column)
 	case ErrCommaSeparatedInvalid:
 		message = fmt.Sprintf("El valor de la columna \"%s\" no es un valor asignable a un arreglo", e.Column)
 	case ErrMaxLengthValueRuleFail:
@@ -64,6 +137,10 @@ func ErrToMessage(e *Error) string {
 		message = fmt.Sprintf("Ocurrió un error desconocido al evaluar el valor de la columna \"%s\"", e.Column)
 	}
 
+	if e.Item > 0 {
+		message = fmt.Sprintf("%s (elemento %d)", message, e.Item)
+	}
+
 	return message
 }
 
@@ -71,9 +148,229 @@ func ErrToMessage(e *Error) string {
  * Layout base structure
  */
 type Layout struct {
-	rows    []interface{}
-	uniques map[string]int
-	errors  []Error
+	rows            []interface{}
+	uniques         map[string]int
+	errors          []Error
+	maxErrors       int
+	maxErrorsPerRow int
+	truncated       bool
+	headerRow       int
+	hasHeaderRow    bool
+	firstDataRow    int
+	lastDataRow     int
+	footerMarker    string
+	skipBlankRows   bool
+	locale          string
+}
+
+/**
+ * Stop the validation process after the first error found
+ */
+func (l *Layout) StopOnFirstError() {
+	l.maxErrors = 1
+}
+
+/**
+ * Limit the total number of errors collected, zero means no limit
+ */
+func (l *Layout) MaxErrors(n int) {
+	l.maxErrors = n
+}
+
+/**
+ * Limit the number of errors collected for a single row, zero means no limit
+ */
+func (l *Layout) MaxErrorsPerRow(n int) {
+	l.maxErrorsPerRow = n
+}
+
+/**
+ * Set the header row number (starting at 1), zero means the file has no header
+ */
+func (l *Layout) HeaderRow(n int) {
+	l.headerRow = n
+	l.hasHeaderRow = true
+}
+
+/**
+ * Set the first data row number (starting at 1), by default the row after the header
+ */
+func (l *Layout) FirstDataRow(n int) {
+	l.firstDataRow = n
+}
+
+/**
+ * Set the last data row number (starting at 1), zero means read until the end
+ */
+func (l *Layout) LastDataRow(n int) {
+	l.lastDataRow = n
+}
+
+/**
+ * Stop reading when the first non empty cell of a row has the marker value
+ * (ex. "TOTAL"), the same value on other cells is read as data
+ */
+func (l *Layout) FooterMarker(marker string) {
+	l.footerMarker = strings.TrimSpace(marker)
+}
+
+/**
+ * Ignore the rows without values instead of validating them
+ */
+func (l *Layout) SkipBlankRows() {
+	l.skipBlankRows = true
+}
+
+/**
+ * Set the locale used to read formatted numbers like "1.234,50" for the fields
+ * without "locale" tag entry
+ */
+func (l *Layout) Locale(locale string) error {
+	if _, exists := findNumberFormat(locale); !exists {
+		return ErrInvalidLocale
+	}
+	l.locale = locale
+	return nil
+}
+
+/**
+ * Return the configured header row number, by default the first table row
+ */
+func (l *Layout) getHeaderRow(table cellRange) int {
+	if l.hasHeaderRow {
+		return l.headerRow
+	}
+	if table.Headless {
+		return 0
+	}
+	return table.FirstRow
+}
+
+/**
+ * Return the configured first data row number
+ */
+func (l *Layout) getFirstDataRow(table cellRange) int {
+	if l.firstDataRow > 0 {
+		return l.firstDataRow
+	}
+	if h := l.getHeaderRow(table); h > 0 {
+		return h + 1
+	}
+	return table.FirstRow
+}
+
+/**
+ * Check if the row number is past the data rows, either by the last data row
+ * option or because the row starts with the footer marker
+ */
+func (l *Layout) isAfterData(rowNumber int, cells []string) bool {
+	if l.lastDataRow > 0 && rowNumber > l.lastDataRow {
+		return true
+	}
+	if l.footerMarker != "" {
+		for _, c := range cells {
+			if c = strings.TrimSpace(c); c != "" {
+				return strings.EqualFold(c, l.footerMarker)
+			}
+		}
+	}
+	return false
+}
+
+/**
+ * Check if the row should be ignored because it has no values
+ */
+func (l *Layout) isSkippedRow(cells []string) bool {
+	if !l.skipBlankRows {
+		return false
+	}
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+/**
+ * Return true when the errors list was truncated by the configured limits
+ */
+func (l *Layout) IsTruncated() bool {
+	return l.truncated
+}
+
+/**
+ * Append row errors applying the configured limits, return false when errors
+ * were discarded by the total errors limit and the process should stop
+ */
+func (l *Layout) appendErrors(errs []Error) bool {
+	errs = l.rowErrors(errs)
+	if l.maxErrors > 0 && len(l.errors)+len(errs) > l.maxErrors {
+		l.errors = append(l.errors, errs[:l.maxErrors-len(l.errors)]...)
+		l.truncated = true
+		return false
+	}
+	l.errors = append(l.errors, errs...)
+	return true
+}
+
+/**
+ * Check the unique rule of a field value, return false when a previous row
+ * has the same value on the field. The empty values are not checked
+ */
+func (l *Layout) isUniqueValue(field string, rowIndex int, f reflect.Value, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		return true
+	}
+	if l.uniques == nil {
+		l.uniques = map[string]int{}
+	}
+	key := fmt.Sprintf("%s\x00%v", field, f.Interface())
+	if _, exists := l.uniques[key]; exists {
+		return false
+	}
+	l.uniques[key] = rowIndex
+	return true
+}
+
+/**
+ * Return the errors of a row kept by the errors per row limit
+ */
+func (l *Layout) rowErrors(errs []Error) []Error {
+	if l.maxErrorsPerRow > 0 && len(errs) > l.maxErrorsPerRow {
+		l.truncated = true
+		return errs[:l.maxErrorsPerRow]
+	}
+	return errs
+}
+
+/**
+ * Return true when the total errors limit has been reached by count errors,
+ * the readers stop before parsing the next row
+ */
+func (l *Layout) isErrorsLimitReached(count int) bool {
+	return l.maxErrors > 0 && count >= l.maxErrors
+}
+
+/**
+ * Append the errors of several rows sorted by row, the limits are applied
+ * to every row like appendErrors
+ */
+func (l *Layout) appendRowErrors(errs []Error) bool {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].RowIndex < errs[j].RowIndex
+	})
+	for start := 0; start < len(errs); {
+		end := start + 1
+		for end < len(errs) && errs[end].RowIndex == errs[start].RowIndex {
+			end++
+		}
+		if !l.appendErrors(errs[start:end]) {
+			return false
+		}
+		start = end
+	}
+	return true
 }
 
 func (l *Layout) CountRows() int {
@@ -84,6 +381,19 @@ func (l *Layout) GetRows() []interface{} {
 	return l.rows
 }
 
+/**
+ * Return the rows read with a runtime layout spec
+ */
+func (l *Layout) GetMapRows() []map[string]interface{} {
+	rows := []map[string]interface{}{}
+	for _, r := range l.rows {
+		if m, ok := r.(map[string]interface{}); ok {
+			rows = append(rows, m)
+		}
+	}
+	return rows
+}
+
 func (l *Layout) GetErrors() []Error {
 	return l.errors
 }
@@ -19,10 +19,39 @@ func TestErrToMessage(t *testing.T) {
 		{Error{Error: ErrUrlValueRuleFail, Column: "A"}, "El valor de la columna \"A\" no es una URL válida"},
 		{Error{Error: ErrEmailValueRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un correo electrónico válido"},
 		{Error{Error: ErrRegexRuleFail, Column: "A"}, "El valor de la columna \"A\" no cumple con la expresión regular"},
+		{Error{Error: ErrPatternRuleFail, Column: "A"}, "El valor de la columna \"A\" no cumple con el formato requerido"},
+		{Error{Error: ErrRfcRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un RFC válido"},
+		{Error{Error: ErrCurpRuleFail, Column: "A"}, "El valor de la columna \"A\" no es una CURP válida"},
+		{Error{Error: ErrClabeRuleFail, Column: "A"}, "El valor de la columna \"A\" no es una CLABE interbancaria válida"},
+		{Error{Error: ErrNssRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un número de seguridad social válido"},
+		{Error{Error: ErrPhoneRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un número telefónico válido"},
+		{Error{Error: ErrZipRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un código postal válido"},
+		{Error{Error: ErrCountryRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un código de país válido"},
+		{Error{Error: ErrCurrencyRuleFail, Column: "A"}, "El valor de la columna \"A\" no es un código de moneda válido"},
+		{Error{Error: ErrDomainRuleFail, Column: "A"}, "El dominio del valor de la columna \"A\" no está permitido"},
+		{Error{Error: ErrMinItemsRuleFail, Column: "A"}, "La lista de la columna \"A\" tiene menos elementos de los permitidos"},
+		{Error{Error: ErrMaxItemsRuleFail, Column: "A"}, "La lista de la columna \"A\" tiene más elementos de los permitidos"},
+		{Error{Error: ErrUniqueItemsRuleFail, Column: "A", Item: 3}, "La lista de la columna \"A\" tiene elementos repetidos (elemento 3)"},
+		{Error{Error: ErrQuotedItemInvalid, Column: "A"}, "La lista de la columna \"A\" tiene un elemento entre comillas sin cerrar"},
+		{Error{Error: ErrEmailValueRuleFail, Column: "A", Item: 2}, "El valor de la columna \"A\" no es un correo electrónico válido (elemento 2)"},
+		{Error{Error: ErrRefRuleFail, Column: "A"}, "El valor de la columna \"A\" no existe en el catálogo referenciado"},
+		{Error{Error: ErrRefCatalogNotFound, Column: "A"}, "No se encontró el catálogo referenciado por la columna \"A\""},
+		{Error{Error: ErrLengthOverflowRuleFail, Column: "A"}, "El valor de la columna \"A\" excede la longitud del campo"},
+		{Error{Error: ErrJSONRowInvalid, RowIndex: 3}, "El registro no es un objeto JSON válido"},
+		{Error{Error: ErrExistsRuleFail, Column: "A"}, "El valor de la columna \"A\" no existe en el sistema"},
 		{Error{Error: ErrRegexInvalid, Column: "A"}, "La expresión regular definida para la columna \"A\" es inválida"},
 		{Error{Error: ErrIntegerInvalid, Column: "A"}, "El valor de la columna \"A\" no es un valor entero válido"},
+		{Error{Error: ErrDateInvalid, Column: "A"}, "El valor de la columna \"A\" no es una fecha válida"},
+		{Error{Error: ErrScaleRuleFail, Column: "A"}, "El valor de la columna \"A\" tiene más decimales de los permitidos"},
+		{Error{Error: ErrPrecisionRuleFail, Column: "A"}, "El valor de la columna \"A\" tiene más dígitos de los permitidos"},
+		{Error{Error: ErrTextValueInvalid, Column: "A"}, "El valor de la columna \"A\" no es válido"},
+		{Error{Error: ErrBoolInvalid, Column: "A"}, "El valor de la columna \"A\" no es un valor booleano válido"},
+		{Error{Error: ErrFormulaRejected, Column: "A"}, "La columna \"A\" contiene una fórmula y solo se permiten valores"},
+		{Error{Error: ErrFormulaInvalid, Column: "A"}, "No se pudo calcular la fórmula de la columna \"A\""},
 		{Error{Error: ErrDecimalInvalid, Column: "A"}, "El valor de la columna \"A\" no es un valor decimal válido"},
 		{Error{Error: ErrNotUnique, Column: "A"}, "El valor de la columna \"A\" debe ser único por archivo"},
+		{Error{Error: ErrEnumRuleFail, Column: "A"}, "El valor de la columna \"A\" no está entre los valores permitidos"},
+		{Error{Error: ErrHeaderNotFound, Column: "Total"}, "No se encontró la columna con encabezado \"Total\""},
 		{Error{Error: ErrCommaSeparatedInvalid, Column: "A"}, "El valor de la columna \"A\" no es un valor asignable a un arreglo"},
 		{Error{Error: ErrMaxLengthValueRuleFail, Column: "A"}, "La longitud del valor de la columna \"A\" es mayor a la permitida"},
 		{Error{Error: ErrMinLengthValueRuleFail, Column: "A"}, "La longitud del valor de la columna \"A\" es menor a la permitida"},
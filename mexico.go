@@ -0,0 +1,123 @@
+package Layouts
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var ErrRfcRuleFail error = errors.New("invalid RFC value")
+var ErrCurpRuleFail error = errors.New("invalid CURP value")
+var ErrClabeRuleFail error = errors.New("invalid CLABE value")
+var ErrNssRuleFail error = errors.New("invalid NSS value")
+
+var rfcRegex = regexp.MustCompile(fullMatchRegex(patterns["rfc"]))
+var curpRegex = regexp.MustCompile(fullMatchRegex(patterns["curp"]))
+var clabeRegex = regexp.MustCompile(`^\d{18}$`)
+var nssRegex = regexp.MustCompile(`^\d{11}$`)
+
+/**
+ * Generic RFC values defined by the SAT, valid without verifier digit
+ */
+var genericRfcs = map[string]bool{
+	"XAXX010101000": true,
+	"XEXX010101000": true,
+}
+
+/**
+ * Return the value of an identifier character on the given dictionary
+ */
+func charValue(dictionary string, r rune) int {
+	i := 0
+	for _, c := range dictionary {
+		if c == r {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+/**
+ * Validate the structure and verifier digit of an RFC, for "personas morales"
+ * (12 characters) and "personas físicas" (13 characters)
+ */
+func isValidRfc(value string) bool {
+	value = strings.ToUpper(value)
+	if genericRfcs[value] {
+		return true
+	}
+	if !rfcRegex.MatchString(value) {
+		return false
+	}
+
+	chars := []rune(value)
+	if len(chars) == 12 {
+		chars = append([]rune{' '}, chars...)
+	}
+	sum := 0
+	for i, c := range chars[:12] {
+		sum += charValue("0123456789ABCDEFGHIJKLMN&OPQRSTUVWXYZ Ñ", c) * (13 - i)
+	}
+	digit := '0'
+	if r := sum % 11; r == 1 {
+		digit = 'A'
+	} else if r > 1 {
+		digit = rune('0' + 11 - r)
+	}
+	return chars[12] == digit
+}
+
+/**
+ * Validate the structure and verifier digit of a CURP
+ */
+func isValidCurp(value string) bool {
+	value = strings.ToUpper(value)
+	if !curpRegex.MatchString(value) {
+		return false
+	}
+
+	sum := 0
+	for i, c := range value[:17] {
+		sum += charValue("0123456789ABCDEFGHIJKLMNÑOPQRSTUVWXYZ", c) * (18 - i)
+	}
+	return int(value[17]-'0') == (10-sum%10)%10
+}
+
+/**
+ * Validate the structure and control digit of a CLABE bank account
+ */
+func isValidClabe(value string) bool {
+	if !clabeRegex.MatchString(value) {
+		return false
+	}
+
+	weights := []int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += int(value[i]-'0') * weights[i%3] % 10
+	}
+	return int(value[17]-'0') == (10-sum%10)%10
+}
+
+/**
+ * Validate the structure and verifier digit (Luhn) of an IMSS social security number
+ */
+func isValidNss(value string) bool {
+	if !nssRegex.MatchString(value) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 10; i++ {
+		d := int(value[i] - '0')
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return int(value[10]-'0') == (10-sum%10)%10
+}
@@ -0,0 +1,109 @@
+package Layouts
+
+import (
+	"testing"
+)
+
+type identifierTests struct {
+	input    string
+	expected bool
+}
+
+func TestRfcValidator(t *testing.T) {
+
+	tests := []identifierTests{
+		{"GODE561231GR8", true},
+		{"gode561231gr8", true},
+		{"SAT970701NN3", true},
+		{"XAXX010101000", true},
+		{"GODE561231GR9", false},
+		{"SAT970701NN4", false},
+		{"GODE561331GR8", false},
+		{"GODE5612", false},
+	}
+
+	for i, test := range tests {
+		if result := isValidRfc(test.input); result != test.expected {
+			t.Errorf("Test %d: Expected %v for \"%s\", Recived: %v", i, test.expected, test.input, result)
+		}
+	}
+}
+
+func TestCurpValidator(t *testing.T) {
+
+	tests := []identifierTests{
+		{"HEGG560427MVZRRL04", true},
+		{"LOOA531113HTCPBN07", true},
+		{"HEGG560427MVZRRL05", false},
+		{"HEGG560427MXXRRL04", false},
+		{"HEGG5604", false},
+	}
+
+	for i, test := range tests {
+		if result := isValidCurp(test.input); result != test.expected {
+			t.Errorf("Test %d: Expected %v for \"%s\", Recived: %v", i, test.expected, test.input, result)
+		}
+	}
+}
+
+func TestClabeValidator(t *testing.T) {
+
+	tests := []identifierTests{
+		{"032180000118359719", true},
+		{"002010077777777771", true},
+		{"032180000118359718", false},
+		{"03218000011835971", false},
+		{"03218000011835971A", false},
+	}
+
+	for i, test := range tests {
+		if result := isValidClabe(test.input); result != test.expected {
+			t.Errorf("Test %d: Expected %v for \"%s\", Recived: %v", i, test.expected, test.input, result)
+		}
+	}
+}
+
+func TestNssValidator(t *testing.T) {
+
+	tests := []identifierTests{
+		{"12345678903", true},
+		{"12345678904", false},
+		{"1234567890", false},
+	}
+
+	for i, test := range tests {
+		if result := isValidNss(test.input); result != test.expected {
+			t.Errorf("Test %d: Expected %v for \"%s\", Recived: %v", i, test.expected, test.input, result)
+		}
+	}
+}
+
+type testEmployeeRow struct {
+	Row
+	RFC   string `excelLayout:"column:A,required,upper,rfc"`
+	CURP  string `excelLayout:"column:B,required,curp"`
+	CLABE string `excelLayout:"column:C,clabe"`
+	NSS   string `excelLayout:"column:D,nss"`
+}
+
+func TestMexicanIdentifiersCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testEmployeeRow{}
+	if errs := l.ParseCells(&row, []string{"gode561231gr8", "HEGG560427MVZRRL04", "032180000118359719", "12345678903"}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+
+	expected := []error{ErrRfcRuleFail, ErrCurpRuleFail, ErrClabeRuleFail, ErrNssRuleFail}
+	errs := l.ParseCells(&testEmployeeRow{}, []string{"GODE561231GR9", "HEGG560427MVZRRL05", "032180000118359718", "12345678904"})
+	if len(errs) != len(expected) {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", expected, errs)
+	}
+	for i, e := range errs {
+		if e.Error != expected[i] {
+			t.Errorf("Test 1: Expected %v, Recived: %v", expected[i], e.Error)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package Layouts
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidLocale error = errors.New("unknown number locale")
+
+/**
+ * Number separators used by a locale
+ */
+type numberFormat struct {
+	Decimal rune
+	Group   rune
+}
+
+/**
+ * Number formats by locale, a locale not found is searched by its language
+ */
+var numberFormats = map[string]numberFormat{
+	"en":    {'.', ','},
+	"es":    {',', '.'},
+	"es-mx": {'.', ','},
+	"es-us": {'.', ','},
+	"es-gt": {'.', ','},
+	"es-pr": {'.', ','},
+	"pt":    {',', '.'},
+	"de":    {',', '.'},
+	"de-ch": {'.', '\''},
+	"it":    {',', '.'},
+	"nl":    {',', '.'},
+	"fr":    {',', ' '},
+	"fr-ch": {'.', '\''},
+	"ru":    {',', ' '},
+	"pl":    {',', ' '},
+	"sv":    {',', ' '},
+	"zh":    {'.', ','},
+	"ja":    {'.', ','},
+}
+
+/**
+ * Return the number format of a locale like "es-MX", "es_ES" or "en"
+ */
+func findNumberFormat(locale string) (numberFormat, bool) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if nf, exists := numberFormats[locale]; exists {
+		return nf, true
+	}
+	if i := strings.Index(locale, "-"); i > 0 {
+		nf, exists := numberFormats[locale[:i]]
+		return nf, exists
+	}
+	return numberFormat{}, false
+}
+
+/**
+ * Convert a formatted number like "$ 1,234.50", "1.234,50 €", "(15.00)" or
+ * "12 %" to a plain number string, return true when the value is a percent.
+ * Only the currency symbols and codes are removed and the group separators
+ * must split the integer digits by three, the last result is false for the
+ * values not recognized as numbers of the locale
+ */
+func normalizeNumber(value string, locale string) (string, bool, bool) {
+	nf, exists := findNumberFormat(locale)
+	value = strings.TrimSpace(value)
+	if !exists || value == "" {
+		return value, false, true
+	}
+
+	number := strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Sc, r) {
+			return -1
+		}
+		return r
+	}, value)
+	number, valid := trimCurrencyCodes(strings.TrimSpace(number))
+	if !valid {
+		return value, false, false
+	}
+
+	negative := false
+	if strings.HasPrefix(number, "(") && strings.HasSuffix(number, ")") {
+		negative = true
+		number = strings.TrimSpace(number[1 : len(number)-1])
+	}
+	percent := false
+	if strings.HasSuffix(number, "%") {
+		percent = true
+		number = strings.TrimSpace(strings.TrimSuffix(number, "%"))
+	}
+	if strings.HasPrefix(number, "-") || strings.HasSuffix(number, "-") {
+		negative = !negative
+		number = strings.Trim(number, "-")
+	}
+	number = strings.TrimPrefix(number, "+")
+	number = strings.TrimFunc(number, unicode.IsSpace)
+
+	plain := strings.Builder{}
+	hasDecimal := false
+	grouped := false
+	digits := 0
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			plain.WriteRune(r)
+			digits++
+		case r == nf.Decimal && !hasDecimal:
+			if grouped && digits != 3 {
+				return value, false, false
+			}
+			hasDecimal = true
+			plain.WriteRune('.')
+		case nf.isGroup(r) && !hasDecimal:
+			if digits == 0 || digits > 3 || (grouped && digits != 3) || (!grouped && strings.HasPrefix(plain.String(), "0")) {
+				return value, false, false
+			}
+			grouped = true
+			digits = 0
+		default:
+			return value, false, false
+		}
+	}
+	if plain.Len() == 0 || (grouped && !hasDecimal && digits != 3) {
+		return value, false, false
+	}
+
+	if negative {
+		return "-" + plain.String(), percent, true
+	}
+	return plain.String(), percent, true
+}
+
+/**
+ * Return true when the character is the group separator, any space is a
+ * group separator on the locales grouping with spaces
+ */
+func (nf numberFormat) isGroup(r rune) bool {
+	return r == nf.Group || (nf.Group == ' ' && unicode.IsSpace(r))
+}
+
+/**
+ * Remove the currency codes like "MXN" before or after the number, false
+ * when the number has other letters
+ */
+func trimCurrencyCodes(number string) (string, bool) {
+	first := strings.IndexFunc(number, func(r rune) bool { return !unicode.IsLetter(r) })
+	if first < 0 {
+		return number, false
+	}
+	if first > 0 {
+		if _, valid := normalizeCurrency(number[:first]); !valid {
+			return number, false
+		}
+		number = strings.TrimSpace(number[first:])
+	}
+	last := strings.LastIndexFunc(number, func(r rune) bool { return !unicode.IsLetter(r) })
+	if last < len(number)-1 {
+		if _, valid := normalizeCurrency(number[last+1:]); !valid {
+			return number, false
+		}
+		number = strings.TrimSpace(number[:last+1])
+	}
+	return number, true
+}
@@ -0,0 +1,105 @@
+package Layouts
+
+import (
+	"testing"
+)
+
+type numberTests struct {
+	input    string
+	locale   string
+	expected string
+	percent  bool
+	valid    bool
+}
+
+func TestNormalizeNumber(t *testing.T) {
+
+	tests := []numberTests{
+		{"1,234.50", "en-US", "1234.50", false, true},
+		{"$ 12.00", "es-MX", "12.00", false, true},
+		{"-$1,000", "es-MX", "-1000", false, true},
+		{"(15.00)", "en", "-15.00", false, true},
+		{"($1,500.25) MXN", "es_MX", "-1500.25", false, true},
+		{"1.234,50", "es-ES", "1234.50", false, true},
+		{"1.234,50 €", "de", "1234.50", false, true},
+		{"1 234,50", "fr-FR", "1234.50", false, true},
+		{"1 234,50", "fr", "1234.50", false, true},
+		{"1'234.50", "de-CH", "1234.50", false, true},
+		{"12%", "en", "12", true, true},
+		{"12,5 %", "es", "12.5", true, true},
+		{"15-", "en", "-15", false, true},
+		{"1.2.3,4", "es", "1.2.3,4", false, false},
+		{"1,5", "en", "1,5", false, false},
+		{"1,234,5", "en", "1,234,5", false, false},
+		{"12,345,678.9", "en", "12345678.9", false, true},
+		{"12abc", "en", "12abc", false, false},
+		{"USD 1,234.50", "en", "1234.50", false, true},
+		{"1 234", "en", "1 234", false, false},
+		{"1,2,3.4.5", "en", "1,2,3.4.5", false, false},
+		{"abc", "en", "abc", false, false},
+		{"1,234.50", "", "1,234.50", false, true},
+		{"1.23", "es", "1.23", false, false},
+		{"0.125", "es", "0.125", false, false},
+		{"1.2345", "es", "1.2345", false, false},
+		{"1.234", "es", "1234", false, true},
+		{"0,125", "es", "0.125", false, true},
+	}
+
+	for i, test := range tests {
+		result, percent, valid := normalizeNumber(test.input, test.locale)
+		if result != test.expected || percent != test.percent || valid != test.valid {
+			t.Errorf("Test %d: Expected \"%s\" (%v %v), Recived: \"%s\" (%v %v)", i, test.expected, test.percent, test.valid, result, percent, valid)
+		}
+	}
+}
+
+type testNumberRow struct {
+	Row
+	Amount   float64 `excelLayout:"column:A,min:-100"`
+	Discount float64 `excelLayout:"column:B,max:1"`
+	Units    int     `excelLayout:"column:C,locale:en"`
+}
+
+func TestLocaleCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+	if err := l.Locale("xx"); err != ErrInvalidLocale {
+		t.Errorf("Test 0: Expected ErrInvalidLocale, Recived: %v", err)
+	}
+	if err := l.Locale("es-ES"); err != nil {
+		t.Errorf("Test 0: Unexpected error: %s", err.Error())
+	}
+
+	row := testNumberRow{}
+	if errs := l.ParseCells(&row, []string{"(15,00 €)", "12,5%", "1,200"}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 1: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	if row.Amount != -15 || row.Discount != 0.125 || row.Units != 1200 {
+		t.Errorf("Test 1: Unexpected row values %+v", row)
+	}
+
+	row = testNumberRow{}
+	if errs := l.ParseCells(&row, []string{"1,5,0", "0", "10%"}); len(errs) != 2 {
+		t.Errorf("Test 2: Expected 2 errors, Recived: %v", errs)
+	}
+
+	row = testNumberRow{}
+	errs := l.ParseCells(&row, []string{"12abc", "0", "1,5"})
+	if len(errs) != 2 || errs[0].Error != ErrDecimalInvalid || errs[1].Error != ErrIntegerInvalid {
+		t.Errorf("Test 3: Expected decimal and integer errors, Recived: %v", errs)
+	}
+
+	type testDotRow struct {
+		Amount float64 `excelLayout:"column:A"`
+		Units  int     `excelLayout:"column:B"`
+		Total  Decimal `excelLayout:"column:C"`
+	}
+	for i, value := range []string{"1.23", "0.125", "1.2345"} {
+		row := testDotRow{}
+		errs := l.ParseCells(&row, []string{value, value, value})
+		if len(errs) != 3 || errs[0].Error != ErrDecimalInvalid || errs[1].Error != ErrIntegerInvalid || errs[2].Error != ErrDecimalInvalid {
+			t.Errorf("Test %d: Expected invalid number errors for %s, Recived: %v", i+4, value, errs)
+		}
+	}
+}
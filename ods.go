@@ -0,0 +1,440 @@
+package Layouts
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrOdsInvalid error = errors.New("invalid OpenDocument spreadsheet file")
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+/**
+ * Greatest number of rows and columns expanded from a repeated row or cell
+ */
+const odsMaxRows = 1048576
+const odsMaxCols = 16384
+
+/**
+ * Cell of an OpenDocument sheet, Text is the displayed value and Value the
+ * typed value stored on the file
+ */
+type odsCell struct {
+	Text    string
+	Value   string
+	Formula string
+}
+
+type odsSheet struct {
+	Name string
+	Rows [][]odsCell
+}
+
+/**
+ * Named range of the document, Scope is the sheet name when the range was
+ * declared inside a sheet
+ */
+type odsName struct {
+	Name  string
+	Scope string
+	Range string
+}
+
+/**
+ * Greatest length of the Excel sheet names and the characters not allowed on
+ * them
+ */
+const odsMaxSheetName = 31
+const odsInvalidSheetChars = `[]:*?/\`
+
+/**
+ * Check if the file is an OpenDocument spreadsheet by its zip "mimetype" entry
+ */
+func isOdsFile(filePath string) bool {
+	r, err := zip.OpenReader(filePath)
+	if err != nil {
+		return false
+	}
+	defer r.Close()
+	mimeType, err := readZipEntry(&r.Reader, "mimetype")
+	return err == nil && strings.TrimSpace(string(mimeType)) == odsMimeType
+}
+
+func readZipEntry(r *zip.Reader, name string) ([]byte, error) {
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, ErrOdsInvalid
+}
+
+/**
+ * Open an OpenDocument spreadsheet as an in memory Excel workbook, so the
+ * sheets are read with the same ranges, headers and rules. The typed cell
+ * values are written when raw is set, the displayed values otherwise
+ */
+func openOdsFile(filePath string, raw bool) (*excelize.File, error) {
+	r, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name != "content.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		sheets, names, err := readOdsContent(rc)
+		if err != nil {
+			return nil, err
+		}
+		return odsToExcel(sheets, names, raw)
+	}
+	return nil, ErrOdsInvalid
+}
+
+func xmlAttr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func repeatCount(se xml.StartElement, name string, limit int) int {
+	n, err := strconv.Atoi(xmlAttr(se, name))
+	if err != nil || n < 1 {
+		return 1
+	}
+	if n > limit {
+		return limit
+	}
+	return n
+}
+
+/**
+ * Decode the sheets and named ranges of the content.xml document, the
+ * repeated rows and cells are expanded except the trailing empty ones
+ */
+func readOdsContent(r io.Reader) ([]odsSheet, []odsName, error) {
+	decoder := xml.NewDecoder(r)
+	sheets := []odsSheet{}
+	names := []odsName{}
+
+	var sheet *odsSheet
+	var row []odsCell
+	var cell *odsCell
+	rowRepeat, cellRepeat := 1, 1
+	emptyRows, emptyCells := 0, 0
+	text := strings.Builder{}
+	paragraphs := 0
+	paragraph := false
+	annotation := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, ErrOdsInvalid
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "table":
+				if t.Name.Space != "" && !strings.Contains(t.Name.Space, "table") {
+					continue
+				}
+				sheets = append(sheets, odsSheet{Name: xmlAttr(t, "name")})
+				sheet = &sheets[len(sheets)-1]
+				emptyRows = 0
+			case "named-range":
+				name := odsName{Name: xmlAttr(t, "name"), Range: xmlAttr(t, "cell-range-address")}
+				if sheet != nil {
+					name.Scope = sheet.Name
+				}
+				names = append(names, name)
+			case "table-row":
+				row = []odsCell{}
+				rowRepeat = repeatCount(t, "number-rows-repeated", odsMaxRows)
+				emptyCells = 0
+			case "table-cell", "covered-table-cell":
+				cell = &odsCell{Formula: xmlAttr(t, "formula")}
+				cellRepeat = repeatCount(t, "number-columns-repeated", odsMaxCols)
+				switch xmlAttr(t, "value-type") {
+				case "float", "percentage", "currency":
+					cell.Value = xmlAttr(t, "value")
+				case "date":
+					cell.Value = strings.Replace(xmlAttr(t, "date-value"), "T", " ", 1)
+				case "boolean":
+					cell.Value = xmlAttr(t, "boolean-value")
+				case "string":
+					cell.Value = xmlAttr(t, "string-value")
+				}
+				text.Reset()
+				paragraphs = 0
+			case "annotation":
+				annotation = true
+			case "p":
+				if annotation {
+					continue
+				}
+				if cell != nil && paragraphs > 0 {
+					text.WriteString("\n")
+				}
+				paragraphs++
+				paragraph = true
+			case "s":
+				if cell != nil {
+					text.WriteString(strings.Repeat(" ", repeatCount(t, "c", odsMaxCols)))
+				}
+			case "tab":
+				if cell != nil {
+					text.WriteString("\t")
+				}
+			case "line-break":
+				if cell != nil {
+					text.WriteString("\n")
+				}
+			}
+		case xml.CharData:
+			if cell != nil && paragraph && !annotation {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "annotation":
+				annotation = false
+			case "p":
+				paragraph = false
+			case "table-cell", "covered-table-cell":
+				if cell == nil || row == nil {
+					continue
+				}
+				cell.Text = text.String()
+				if cell.Value == "" {
+					cell.Value = cell.Text
+				}
+				if cell.Text == "" {
+					cell.Text = cell.Value
+				}
+				if cell.Text == "" && cell.Value == "" && cell.Formula == "" {
+					emptyCells += cellRepeat
+				} else {
+					for ; emptyCells > 0 && len(row) < odsMaxCols; emptyCells-- {
+						row = append(row, odsCell{})
+					}
+					for i := 0; i < cellRepeat && len(row) < odsMaxCols; i++ {
+						row = append(row, *cell)
+					}
+				}
+				cell = nil
+			case "table-row":
+				if sheet == nil || row == nil {
+					continue
+				}
+				if len(row) == 0 {
+					emptyRows += rowRepeat
+				} else {
+					for ; emptyRows > 0 && len(sheet.Rows) < odsMaxRows; emptyRows-- {
+						sheet.Rows = append(sheet.Rows, []odsCell{})
+					}
+					for i := 0; i < rowRepeat && len(sheet.Rows) < odsMaxRows; i++ {
+						sheet.Rows = append(sheet.Rows, row)
+					}
+				}
+				row = nil
+			case "table":
+				if t.Name.Space == "" || strings.Contains(t.Name.Space, "table") {
+					sheet = nil
+				}
+			}
+		}
+	}
+
+	if len(sheets) == 0 {
+		return nil, nil, ErrOdsInvalid
+	}
+	return sheets, names, nil
+}
+
+var odsRangeRef = regexp.MustCompile(`\[\$?'?([^\]]*?)'?\.\$?([A-Z]+)\$?([0-9]+)(?::\$?'?[^\]]*?'?\.\$?([A-Z]+)\$?([0-9]+))?\]`)
+
+/**
+ * Convert an OpenFormula expression like "of:=SUM([.A1:.B2];[Hoja2.C3])" to
+ * the Excel syntax "SUM(A1:B2,Hoja2!C3)", the sheets are renamed with the
+ * sheetNames map
+ */
+func odsFormula(formula string, sheetNames map[string]string) string {
+	if i := strings.Index(formula, ":="); i >= 0 && i < 6 {
+		formula = formula[i+2:]
+	}
+	formula = strings.TrimPrefix(formula, "=")
+	formula = odsRangeRef.ReplaceAllStringFunc(formula, func(ref string) string {
+		m := odsRangeRef.FindStringSubmatch(ref)
+		result := m[2] + m[3]
+		if m[1] != "" {
+			result = "'" + odsSheetName(m[1], sheetNames) + "'!" + result
+		}
+		if m[4] != "" {
+			result += ":" + m[4] + m[5]
+		}
+		return result
+	})
+
+	result := strings.Builder{}
+	quoted := false
+	for _, r := range formula {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ';' && !quoted {
+			r = ','
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
+
+func odsSheetName(name string, sheetNames map[string]string) string {
+	if renamed, found := sheetNames[name]; found {
+		return renamed
+	}
+	return name
+}
+
+/**
+ * Map the sheet names to valid and unique Excel sheet names, the characters
+ * not allowed are replaced by "_" and the names are cut to 31 characters
+ */
+func odsSheetNames(sheets []odsSheet) map[string]string {
+	result := map[string]string{}
+	used := map[string]bool{}
+	for i, sheet := range sheets {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(odsInvalidSheetChars, r) {
+				return '_'
+			}
+			return r
+		}, sheet.Name)
+		name = strings.Trim(name, "'")
+		if name == "" {
+			name = "Sheet" + strconv.Itoa(i+1)
+		}
+		unique := []rune(name)
+		if len(unique) > odsMaxSheetName {
+			unique = unique[:odsMaxSheetName]
+		}
+		for n := 2; used[strings.ToLower(string(unique))]; n++ {
+			suffix := []rune(" (" + strconv.Itoa(n) + ")")
+			unique = []rune(name)
+			if len(unique)+len(suffix) > odsMaxSheetName {
+				unique = []rune(strings.TrimRight(string(unique[:odsMaxSheetName-len(suffix)]), " "))
+			}
+			unique = append(unique, suffix...)
+		}
+		used[strings.ToLower(string(unique))] = true
+		result[sheet.Name] = string(unique)
+	}
+	return result
+}
+
+/**
+ * Convert a cell range address like "$Hoja1.$A$1:.$C$5" to the absolute
+ * Excel reference "'Hoja1'!$A$1:$C$5", an empty string is returned when the
+ * address is not a single range
+ */
+func odsRangeAddress(address string, sheetNames map[string]string) string {
+	quoted := false
+	for _, r := range address {
+		if r == '\'' {
+			quoted = !quoted
+		}
+		if r == ' ' && !quoted {
+			return ""
+		}
+	}
+	m := odsRangeRef.FindStringSubmatch("[" + address + "]")
+	if m == nil || len(m[0]) != len(address)+2 || m[1] == "" {
+		return ""
+	}
+	result := "'" + odsSheetName(m[1], sheetNames) + "'!$" + m[2] + "$" + m[3]
+	if m[4] != "" {
+		result += ":$" + m[4] + "$" + m[5]
+	}
+	return result
+}
+
+/**
+ * Write the OpenDocument sheets on a new Excel workbook, the named ranges
+ * are written as defined names
+ */
+func odsToExcel(sheets []odsSheet, names []odsName, raw bool) (*excelize.File, error) {
+	xlsx := excelize.NewFile()
+	sheetNames := odsSheetNames(sheets)
+	for i, sheet := range sheets {
+		sheet.Name = sheetNames[sheet.Name]
+		if i == 0 {
+			xlsx.SetSheetName(xlsx.GetSheetName(0), sheet.Name)
+		} else {
+			xlsx.NewSheet(sheet.Name)
+		}
+		for r, row := range sheet.Rows {
+			for c, cell := range row {
+				value := cell.Text
+				if raw {
+					value = cell.Value
+				}
+				if value == "" && cell.Formula == "" {
+					continue
+				}
+				axis, _ := excelize.CoordinatesToCellName(c+1, r+1)
+				if err := xlsx.SetCellStr(sheet.Name, axis, value); err != nil {
+					return nil, err
+				}
+				if cell.Formula != "" {
+					if err := xlsx.SetCellFormula(sheet.Name, axis, odsFormula(cell.Formula, sheetNames)); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range names {
+		ref := odsRangeAddress(name.Range, sheetNames)
+		if name.Name == "" || ref == "" {
+			continue
+		}
+		dn := &excelize.DefinedName{Name: name.Name, RefersTo: ref}
+		if name.Scope != "" {
+			dn.Scope = sheetNames[name.Scope]
+		}
+		if err := xlsx.SetDefinedName(dn); err != nil {
+			return nil, err
+		}
+	}
+	return xlsx, nil
+}
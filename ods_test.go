@@ -0,0 +1,258 @@
+package Layouts
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testOdsContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+	xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2">
+<office:body><office:spreadsheet>
+<table:table table:name="Productos">
+	<table:table-row>
+		<table:table-cell office:value-type="string"><text:p>ID</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Nombre</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Alta</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Descuento</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Total</text:p></table:table-cell>
+	</table:table-row>
+	<table:table-row>
+		<table:table-cell office:value-type="float" office:value="1"><text:p>1</text:p></table:table-cell>
+		<table:table-cell office:value-type="string">
+			<office:annotation><text:p>Revisar</text:p></office:annotation>
+			<text:p>Tornillo<text:s text:c="2"/>largo</text:p>
+		</table:table-cell>
+		<table:table-cell office:value-type="date" office:date-value="2022-06-01"><text:p>01/06/22</text:p></table:table-cell>
+		<table:table-cell office:value-type="percentage" office:value="0.15"><text:p>15%</text:p></table:table-cell>
+		<table:table-cell table:formula="of:=SUM([.A2];[.D2])" office:value-type="float" office:value="1.15"><text:p>1.15</text:p></table:table-cell>
+	</table:table-row>
+	<table:table-row table:number-rows-repeated="2">
+		<table:table-cell office:value-type="float" office:value="2"><text:p>2</text:p></table:table-cell>
+		<table:table-cell table:number-columns-repeated="2"/>
+		<table:table-cell office:value-type="percentage" office:value="0.1"><text:p>10%</text:p></table:table-cell>
+		<table:table-cell table:number-columns-repeated="1020"/>
+	</table:table-row>
+	<table:table-row table:number-rows-repeated="1048570">
+		<table:table-cell table:number-columns-repeated="1024"/>
+	</table:table-row>
+</table:table>
+<table:table table:name="Hoja2">
+	<table:table-row><table:table-cell office:value-type="string"><text:p>x</text:p></table:table-cell></table:table-row>
+</table:table>
+<table:table table:name="Resumen de ventas y productos del mes">
+	<table:table-row>
+		<table:table-cell office:value-type="string"><text:p>ID</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Nombre</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Alta</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Descuento</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Total</text:p></table:table-cell>
+	</table:table-row>
+	<table:table-row>
+		<table:table-cell office:value-type="float" office:value="3"><text:p>3</text:p></table:table-cell>
+		<table:table-cell table:number-columns-repeated="2"/>
+		<table:table-cell office:value-type="percentage" office:value="0.2"><text:p>20%</text:p></table:table-cell>
+		<table:table-cell table:formula="of:=[$'Resumen de ventas y productos del mes'.A2]*2" office:value-type="float" office:value="6"><text:p>6</text:p></table:table-cell>
+	</table:table-row>
+	<table:named-expressions>
+		<table:named-range table:name="Resumen" table:base-cell-address="$'Resumen de ventas y productos del mes'.$A$1" table:cell-range-address="$'Resumen de ventas y productos del mes'.$A$1:.$E$2"/>
+	</table:named-expressions>
+</table:table>
+<table:named-expressions>
+	<table:named-range table:name="Lista" table:base-cell-address="$Productos.$A$1" table:cell-range-address="$Productos.$A$1:.$E$2"/>
+	<table:named-range table:name="Varios" table:cell-range-address="$Productos.$A$1:.$A$2 $Hoja2.$A$1"/>
+</table:named-expressions>
+</office:spreadsheet></office:body>
+</office:document-content>`
+
+func createTestOdsFile(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "test.ods")
+	file, err := os.Create(fileName)
+	if err != nil {
+		t.Fatalf("Unable to create test file: %s", err.Error())
+	}
+	defer file.Close()
+
+	w := zip.NewWriter(file)
+	entries := []struct{ name, content string }{
+		{"mimetype", odsMimeType},
+		{"content.xml", testOdsContent},
+	}
+	for _, e := range entries {
+		f, err := w.Create(e.name)
+		if err != nil {
+			t.Fatalf("Unable to write test file: %s", err.Error())
+		}
+		f.Write([]byte(e.content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Unable to write test file: %s", err.Error())
+	}
+	return fileName
+}
+
+func TestReadOdsContent(t *testing.T) {
+	sheets, names, err := readOdsContent(strings.NewReader(testOdsContent))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sheets) != 3 || sheets[0].Name != "Productos" || sheets[1].Name != "Hoja2" {
+		t.Fatalf("Expected sheets Productos, Hoja2 and Resumen, Recived: %v", sheets)
+	}
+	expectedNames := []odsName{
+		{Name: "Resumen", Scope: sheets[2].Name, Range: "$'Resumen de ventas y productos del mes'.$A$1:.$E$2"},
+		{Name: "Lista", Range: "$Productos.$A$1:.$E$2"},
+		{Name: "Varios", Range: "$Productos.$A$1:.$A$2 $Hoja2.$A$1"},
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("Expected %v, Recived: %v", expectedNames, names)
+	}
+	if len(sheets[0].Rows) != 4 {
+		t.Fatalf("Expected 4 rows, Recived: %d", len(sheets[0].Rows))
+	}
+	expected := []odsCell{
+		{Text: "1", Value: "1"},
+		{Text: "Tornillo  largo", Value: "Tornillo  largo"},
+		{Text: "01/06/22", Value: "2022-06-01"},
+		{Text: "15%", Value: "0.15"},
+		{Text: "1.15", Value: "1.15", Formula: "of:=SUM([.A2];[.D2])"},
+	}
+	if !reflect.DeepEqual(sheets[0].Rows[1], expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, sheets[0].Rows[1])
+	}
+	if row := sheets[0].Rows[3]; len(row) != 4 || row[3].Value != "0.1" {
+		t.Errorf("Expected the repeated row with 4 cells, Recived: %v", row)
+	}
+}
+
+func TestOdsFormula(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"of:=SUM([.A1:.B2];[.C3])", "SUM(A1:B2,C3)"},
+		{"of:=[$Hoja2.$A$1]*2", "'Hoja2'!A1*2"},
+		{`of:=IF([.A1]>0;"a;b";"c")`, `IF(A1>0,"a;b","c")`},
+	}
+
+	for i, test := range tests {
+		if result := odsFormula(test.input, nil); result != test.expected {
+			t.Errorf("Test %d: Expected %s, Recived: %s", i, test.expected, result)
+		}
+	}
+}
+
+func TestOdsSheetNames(t *testing.T) {
+	sheets := []odsSheet{
+		{Name: "Resumen 2022/06 [borrador] de productos"},
+		{Name: "Resumen 2022_06 _borrador_ de productos"},
+		{Name: "Ventas: ¿total?"},
+		{Name: "'*'"},
+	}
+	expected := map[string]string{
+		"Resumen 2022/06 [borrador] de productos": "Resumen 2022_06 _borrador_ de p",
+		"Resumen 2022_06 _borrador_ de productos": "Resumen 2022_06 _borrador_ (2)",
+		"Ventas: ¿total?":                         "Ventas_ ¿total_",
+		"'*'":                                     "_",
+	}
+	if result := odsSheetNames(sheets); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, result)
+	}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"$Productos.$A$1:.$E$2", "'Productos'!$A$1:$E$2"},
+		{"$'Ventas: ¿total?'.$B$3", "'Ventas_ ¿total_'!$B$3"},
+		{"$Productos.$A$1:.$A$2 $Hoja2.$A$1", ""},
+		{".A1", ""},
+	}
+	for i, test := range tests {
+		if result := odsRangeAddress(test.input, expected); result != test.expected {
+			t.Errorf("Test %d: Expected %s, Recived: %s", i, test.expected, result)
+		}
+	}
+}
+
+type testOdsRow struct {
+	Row
+	ID       int64     `excelLayout:"header:ID,required"`
+	Name     string    `excelLayout:"header:Nombre,collapseSpaces"`
+	Date     time.Time `excelLayout:"header:Alta"`
+	Discount float64   `excelLayout:"header:Descuento"`
+	Total    float64   `excelLayout:"header:Total"`
+}
+
+func TestOdsFileRead(t *testing.T) {
+	fileName := createTestOdsFile(t)
+
+	l := ExcelLayout{}
+	l.RawValues()
+	l.Formulas(FormulaEvaluate)
+	if err := l.ReadFile(testOdsRow{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	expected := []interface{}{
+		&testOdsRow{Row: Row{Index: 2}, ID: 1, Name: "Tornillo largo", Date: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), Discount: 0.15, Total: 1.15},
+		&testOdsRow{Row: Row{Index: 3}, ID: 2, Discount: 0.1},
+		&testOdsRow{Row: Row{Index: 4}, ID: 2, Discount: 0.1},
+	}
+	if rows := l.GetRows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, rows)
+	}
+
+	l = ExcelLayout{}
+	l.Formulas(FormulaReject)
+	if err := l.ReadFile(testOdsRow{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expectedErrors := []Error{
+		{RowIndex: 2, Column: "E", Error: ErrFormulaRejected},
+		{RowIndex: 2, Column: "C", Error: ErrDateInvalid},
+	}
+	errs := l.GetErrors()
+	for i, e := range expectedErrors {
+		if i >= len(errs) || errs[i] != e {
+			t.Errorf("Test 1: Expected %v, Recived: %v", expectedErrors, errs)
+			break
+		}
+	}
+
+	l = ExcelLayout{}
+	l.Range("Hoja2!A1")
+	if err := l.ReadFile(testOdsRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 2: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+
+	l = ExcelLayout{}
+	l.RawValues()
+	l.Range("Lista")
+	if err := l.ReadFile(testOdsRow{}, fileName); err != nil {
+		t.Fatalf("Test 3: Unexpected error: %v %v", err, l.GetErrors())
+	}
+	if rows := l.GetRows(); !reflect.DeepEqual(rows, expected[:1]) {
+		t.Errorf("Test 3: Expected %v, Recived: %v", expected[:1], rows)
+	}
+
+	l = ExcelLayout{}
+	l.RawValues()
+	l.Formulas(FormulaEvaluate)
+	l.Range("Resumen")
+	if err := l.ReadFile(testOdsRow{}, fileName); err != nil {
+		t.Fatalf("Test 4: Unexpected error: %v %v", err, l.GetErrors())
+	}
+	expected = []interface{}{&testOdsRow{Row: Row{Index: 2}, ID: 3, Discount: 0.2, Total: 6}}
+	if rows := l.GetRows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test 4: Expected %v, Recived: %v", expected, rows)
+	}
+}
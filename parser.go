@@ -1,17 +1,24 @@
 package Layouts
 
 import (
+	"encoding"
 	"errors"
-	"net/mail"
-	"net/url"
-	"regexp"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/xuri/excelize/v2"
 )
 
 var ErrTagNoFieldTag error = errors.New("no \"excelLayout\" tag found")
 var ErrTagEmptyFieldTag error = errors.New("empty \"excelLayout\" tag found")
 var ErrTagMissingColumnValue error = errors.New("expected value for \"column\" tag entry")
+var ErrTagMissingHeaderValue error = errors.New("expected value for \"header\" tag entry")
+var ErrTagMissingOffsetValue error = errors.New("expected value for \"offset\" tag entry")
+var ErrTagMissingDefaultValue error = errors.New("expected value for \"default\" tag entry")
+var ErrTagMissingFormatValue error = errors.New("expected value for \"format\" tag entry")
+var ErrTagMissingEnumValue error = errors.New("expected value for \"enum\" tag entry")
 var ErrTagMissingRegexValue error = errors.New("expected value for \"regex\" tag entry")
 var ErrTagMissingMaxValue error = errors.New("expected value for \"max\" tag entry")
 var ErrTagMissingMinValue error = errors.New("expected value for \"min\" tag entry")
@@ -35,25 +42,94 @@ var ErrRegexRuleFail error = errors.New("regex matching rule fail")
 var ErrRegexInvalid error = errors.New("invalid regex value")
 var ErrIntegerInvalid error = errors.New("invalid integer value")
 var ErrDecimalInvalid error = errors.New("invalid integer value")
+var ErrDateInvalid error = errors.New("invalid date value")
+var ErrTextValueInvalid error = errors.New("invalid value for the field type")
+var ErrBoolInvalid error = errors.New("invalid boolean value")
+var ErrFormulaRejected error = errors.New("formula cells are not allowed")
+var ErrFormulaInvalid error = errors.New("formula evaluation fail")
 var ErrCommaSeparatedInvalid error = errors.New("invalid comma separated expected value")
 var ErrNotUnique error = errors.New("value is not unique")
+var ErrEnumRuleFail error = errors.New("value not in allowed values list")
+var ErrHeaderNotFound error = errors.New("column header not found")
 
 type fieldTags struct {
 	Column              string
+	Columns             []columnSpan
+	Header              string
+	Offset              string
+	Prefix              string
 	CommaSeparatedValue bool
+	Separator           string
+	MinItems            int64
+	MaxItems            int64
+	UniqueItems         bool
+	DropEmpty           bool
 	Email               bool
+	StrictEmail         bool
 	Required            bool
 	Regex               string
+	FullMatch           bool
+	Pattern             string
 	Max                 float64
 	Min                 float64
 	MaxLength           int64
 	MinLength           int64
 	Url                 bool
+	StrictUrl           bool
+	Schemes             []string
+	RequireHost         bool
+	Domains             []string
+	DenyDomains         []string
 	Unique              bool
+	Key                 bool
+	Ref                 string
+	Exists              string
+	Detail              bool
+	Start               int64
+	Length              int64
+	Align               string
+	Pad                 string
+	Enum                []string
+	Rfc                 bool
+	Curp                bool
+	Clabe               bool
+	Nss                 bool
+	Phone               bool
+	PhoneCountry        string
+	Zip                 []string
+	Country             bool
+	CountryFormat       string
+	Currency            bool
+	Normalize           bool
+	Transforms          []transform
+	Default             string
+	Format              string
+	Locale              string
+	Scale               int64
+	Precision           int64
 	hasMin              bool
 	hasMax              bool
 	hasMinLength        bool
 	hasMaxLength        bool
+	hasDefault          bool
+	hasScale            bool
+	hasPrecision        bool
+	hasMinItems         bool
+	hasMaxItems         bool
+	serialDates         bool
+	minText             string
+	maxText             string
+}
+
+/**
+ * Date layouts accepted when the field has no "format" tag entry, the last one
+ * is the format used by the Excel reader for the default date cells style
+ */
+var defaultDateFormats = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"01-02-06",
 }
 
 func parseOptions(tags string) (fieldTags, error) {
@@ -88,18 +164,30 @@ func parseOptions(tags string) (fieldTags, error) {
 	if len(tags) < 1 {
 		return ft, ErrTagEmptyFieldTag
 	}
-	options := strings.Split(tags, ",")
+	options, err := splitOptions(tags)
+	if err != nil {
+		return ft, err
+	}
 
 	if len(options) == 0 {
 		return ft, ErrTagEmptyFieldTag
 	}
 
-	for _, o := range options {
+	for i := 0; i < len(options); i++ {
+		o := options[i]
 		pair := strings.SplitN(o, ":", 2)
 		key := strings.ToLower(strings.TrimSpace(pair[0]))
 		val := ""
 		if len(pair) > 1 {
-			val = strings.TrimSpace(pair[1])
+			val = unquoteValue(strings.TrimSpace(pair[1]))
+		}
+
+		if t, ok, err := parseTransform(key, val); ok {
+			if err != nil {
+				return ft, err
+			}
+			ft.Transforms = append(ft.Transforms, t)
+			continue
 		}
 
 		switch key {
@@ -107,16 +195,80 @@ func parseOptions(tags string) (fieldTags, error) {
 			if val == "" {
 				return ft, ErrTagMissingColumnValue
 			}
-			ft.Column = strings.TrimSpace(strings.ToUpper(pair[1]))
+			ft.Column = strings.ToUpper(val)
+		case "columns":
+			if val == "" {
+				return ft, ErrTagMissingColumnsValue
+			}
+			for ; i+1 < len(options) && isColumnsItem(options[i+1]); i++ {
+				val += "," + options[i+1]
+			}
+			spans, err := parseColumnSpans(val)
+			if err != nil {
+				return ft, err
+			}
+			ft.Columns = spans
+		case "header":
+			if val == "" {
+				return ft, ErrTagMissingHeaderValue
+			}
+			ft.Header = val
+		case "offset":
+			if val == "" {
+				return ft, ErrTagMissingOffsetValue
+			}
+			ft.Offset = strings.ToUpper(val)
+		case "prefix":
+			ft.Prefix = val
 		case "commaseparatedvalue":
 			ft.CommaSeparatedValue = true
+		case "separator":
+			if val == "" {
+				return ft, ErrTagMissingSeparatorValue
+			}
+			if name, exists := separatorNames[strings.ToLower(val)]; exists {
+				val = name
+			}
+			ft.CommaSeparatedValue = true
+			ft.Separator = val
+		case "minitems":
+			if val == "" {
+				return ft, ErrTagMissingMinItemsValue
+			}
+			ft.CommaSeparatedValue = true
+			ft.hasMinItems = true
+			ft.MinItems, _ = strconv.ParseInt(val, 0, 32)
+		case "maxitems":
+			if val == "" {
+				return ft, ErrTagMissingMaxItemsValue
+			}
+			ft.CommaSeparatedValue = true
+			ft.hasMaxItems = true
+			ft.MaxItems, _ = strconv.ParseInt(val, 0, 32)
+		case "uniqueitems":
+			ft.CommaSeparatedValue = true
+			ft.UniqueItems = true
+		case "dropempty":
+			ft.CommaSeparatedValue = true
+			ft.DropEmpty = true
 		case "regex":
 			if val == "" {
 				return ft, ErrTagMissingRegexValue
 			}
-			ft.Regex = strings.TrimSpace(pair[1])
+			ft.Regex = val
+		case "fullmatch":
+			ft.FullMatch = true
+		case "pattern":
+			if val == "" {
+				return ft, ErrTagMissingPatternValue
+			}
+			ft.Pattern = strings.ToLower(val)
 		case "email":
+			if val != "" && strings.ToLower(val) != "strict" {
+				return ft, ErrTagInvalidEmailMode
+			}
 			ft.Email = true
+			ft.StrictEmail = val != ""
 		case "required":
 			ft.Required = true
 		case "max":
@@ -126,6 +278,7 @@ func parseOptions(tags string) (fieldTags, error) {
 			ft.hasMax = true
 			v, _ := strconv.ParseFloat(val, 32)
 			ft.Max = v
+			ft.maxText = val
 		case "min":
 			if val == "" {
 				return ft, ErrTagMissingMinValue
@@ -133,6 +286,7 @@ func parseOptions(tags string) (fieldTags, error) {
 			ft.hasMin = true
 			v, _ := strconv.ParseFloat(val, 32)
 			ft.Min = v
+			ft.minText = val
 		case "maxlength":
 			if val == "" {
 				return ft, ErrTagMissingMaxValue
@@ -148,28 +302,228 @@ func parseOptions(tags string) (fieldTags, error) {
 			v, _ := strconv.ParseInt(val, 0, 32)
 			ft.MinLength = v
 		case "url":
+			if val != "" && strings.ToLower(val) != "strict" {
+				return ft, ErrTagInvalidUrlMode
+			}
+			ft.Url = true
+			ft.StrictUrl = val != ""
+		case "schemes":
+			if val == "" {
+				return ft, ErrTagMissingSchemesValue
+			}
+			ft.Url = true
+			ft.Schemes = splitLowerList(val)
+		case "requirehost":
 			ft.Url = true
+			ft.RequireHost = true
+		case "domains":
+			if val == "" {
+				return ft, ErrTagMissingDomainsValue
+			}
+			ft.Domains = splitLowerList(val)
+		case "denydomains":
+			if val == "" {
+				return ft, ErrTagMissingDenyDomainsValue
+			}
+			ft.DenyDomains = splitLowerList(val)
 		case "unique":
 			ft.Unique = true
+		case "key":
+			ft.Key = true
+		case "exists":
+			if val == "" {
+				return ft, ErrTagMissingExistsValue
+			}
+			ft.Exists = val
+		case "ref":
+			if _, _, ok := splitRef(val); !ok {
+				return ft, ErrTagInvalidRefValue
+			}
+			ft.Ref = val
+		case "detail":
+			ft.Detail = true
+		case "pos":
+			start, length, err := parsePosition(val)
+			if err != nil {
+				return ft, err
+			}
+			ft.Start, ft.Length = start, length
+		case "start":
+			if ft.Start, _ = strconv.ParseInt(val, 10, 32); ft.Start < 1 {
+				return ft, ErrTagMissingStartValue
+			}
+		case "length":
+			if ft.Length, _ = strconv.ParseInt(val, 10, 32); ft.Length < 1 {
+				return ft, ErrTagMissingLengthValue
+			}
+		case "align":
+			if val = strings.ToLower(val); val != "left" && val != "right" {
+				return ft, ErrTagInvalidAlignValue
+			}
+			ft.Align = val
+		case "pad":
+			if utf8.RuneCountInString(val) != 1 {
+				return ft, ErrTagInvalidPadValue
+			}
+			ft.Pad = val
+		case "rfc":
+			ft.Rfc = true
+		case "curp":
+			ft.Curp = true
+		case "clabe":
+			ft.Clabe = true
+		case "nss":
+			ft.Nss = true
+		case "phone":
+			ft.Phone = true
+			ft.PhoneCountry = strings.ToUpper(val)
+		case "zip":
+			if val == "" {
+				return ft, ErrTagMissingZipValue
+			}
+			for _, c := range strings.Split(val, "|") {
+				ft.Zip = append(ft.Zip, strings.ToUpper(strings.TrimSpace(c)))
+			}
+		case "country":
+			ft.Country = true
+			ft.CountryFormat = strings.ToLower(val)
+		case "currency":
+			ft.Currency = true
+		case "normalize":
+			ft.Normalize = true
+		case "default":
+			if val == "" {
+				return ft, ErrTagMissingDefaultValue
+			}
+			ft.Default = val
+			ft.hasDefault = true
+		case "scale":
+			if val == "" {
+				return ft, ErrTagMissingScaleValue
+			}
+			ft.hasScale = true
+			ft.Scale, _ = strconv.ParseInt(val, 0, 32)
+		case "precision":
+			if val == "" {
+				return ft, ErrTagMissingPrecisionValue
+			}
+			ft.hasPrecision = true
+			ft.Precision, _ = strconv.ParseInt(val, 0, 32)
+		case "locale":
+			if _, exists := findNumberFormat(val); !exists {
+				return ft, ErrInvalidLocale
+			}
+			ft.Locale = val
+		case "format":
+			if val == "" {
+				return ft, ErrTagMissingFormatValue
+			}
+			ft.Format = val
+		case "enum":
+			if val == "" {
+				return ft, ErrTagMissingEnumValue
+			}
+			for _, e := range strings.Split(val, "|") {
+				ft.Enum = append(ft.Enum, strings.TrimSpace(e))
+			}
 		}
 
 	}
 
+	return ft, ft.validate()
+}
+
+/**
+ * Check the consistency of the field options
+ */
+func (ft *fieldTags) validate() error {
 	if ft.Regex != "" {
-		if _, err := regexp.Compile(ft.Regex); err != nil {
-			return ft, ErrRegexInvalid
+		if _, err := compileRegex(ft.Regex); err != nil {
+			return ErrRegexInvalid
+		}
+	}
+
+	if ft.Pattern != "" {
+		if _, exists := findPattern(ft.Pattern); !exists {
+			return ErrTagUnknownPattern
 		}
 	}
 
+	if (len(ft.Domains) > 0 || len(ft.DenyDomains) > 0) && !ft.Url && !ft.Email {
+		return ErrTagDomainsForbidden
+	}
+
+	if ft.PhoneCountry != "" {
+		if _, exists := findCountry(ft.PhoneCountry); !exists {
+			return ErrTagUnknownCountry
+		}
+	}
+
+	for _, c := range ft.Zip {
+		if _, exists := zipFormats[c]; !exists {
+			return ErrTagUnknownZipCountry
+		}
+	}
+
+	if ft.CountryFormat != "" && ft.CountryFormat != "alpha2" && ft.CountryFormat != "alpha3" {
+		return ErrTagInvalidCountryFormat
+	}
+
+	if ft.Start > 0 && ft.Length == 0 {
+		return ErrTagMissingLengthValue
+	}
+
+	if ft.Length > 0 && ft.Start == 0 {
+		return ErrTagMissingStartValue
+	}
+
 	if (ft.hasMax && ft.hasMin) && (ft.Max < ft.Min) {
-		return ft, ErrTagInvalidMaxMinValues
+		return ErrTagInvalidMaxMinValues
 	}
 
 	if (ft.hasMaxLength && ft.hasMinLength) && (ft.MaxLength < ft.MinLength) {
-		return ft, ErrTagInvalidMaxMinLengthValues
+		return ErrTagInvalidMaxMinLengthValues
+	}
+
+	if (ft.hasMaxItems && ft.hasMinItems) && (ft.MaxItems < ft.MinItems) {
+		return ErrTagInvalidMaxMinItemsValues
 	}
 
-	return ft, nil
+	if (ft.hasPrecision && ft.hasScale) && (ft.Precision < ft.Scale) {
+		return ErrTagInvalidPrecisionScaleValues
+	}
+
+	return nil
+}
+
+/**
+ * Check if the value is in the allowed values list, if any
+ */
+func (ft *fieldTags) inEnum(value string) bool {
+	if len(ft.Enum) == 0 {
+		return true
+	}
+	for _, e := range ft.Enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Check if the number is in the allowed values list, if any
+ */
+func (ft *fieldTags) inNumberEnum(value float64) bool {
+	if len(ft.Enum) == 0 {
+		return true
+	}
+	for _, e := range ft.Enum {
+		if v, err := strconv.ParseFloat(e, 64); err == nil && v == value {
+			return true
+		}
+	}
+	return false
 }
 
 func parseStringRules(v string, tags fieldTags) (string, []error) {
@@ -192,25 +546,70 @@ func parseStringRules(v string, tags fieldTags) (string, []error) {
 		if tags.hasMaxLength && (int(tags.MaxLength) < len(value)) {
 			errors = append(errors, ErrMaxLengthValueRuleFail)
 		}
+		if !tags.inEnum(value) {
+			errors = append(errors, ErrEnumRuleFail)
+		}
 		if tags.Url {
-			if _, err := url.ParseRequestURI(value); err != nil {
-				errors = append(errors, ErrUrlValueRuleFail)
+			if normalized, err := checkUrl(value, tags); err != nil {
+				errors = append(errors, err)
+			} else if tags.Normalize {
+				value = normalized
 			}
 		}
 		if tags.Email {
-			if _, err := mail.ParseAddress(value); err != nil {
-				errors = append(errors, ErrEmailValueRuleFail)
+			if normalized, err := checkEmail(value, tags); err != nil {
+				errors = append(errors, err)
+			} else if tags.Normalize {
+				value = normalized
 			}
 		}
 		if tags.Regex != "" {
-			regex, err := regexp.Compile(tags.Regex)
-			if err != nil {
-				errors = append(errors, ErrRegexInvalid)
+			expr := tags.Regex
+			if tags.FullMatch {
+				expr = fullMatchRegex(expr)
 			}
-			if match := regex.MatchString(value); !match {
+			if regex, err := compileRegex(expr); err != nil {
+				errors = append(errors, ErrRegexInvalid)
+			} else if match := regex.MatchString(value); !match {
 				errors = append(errors, ErrRegexRuleFail)
 			}
 		}
+		if tags.Rfc && !isValidRfc(value) {
+			errors = append(errors, ErrRfcRuleFail)
+		}
+		if tags.Curp && !isValidCurp(value) {
+			errors = append(errors, ErrCurpRuleFail)
+		}
+		if tags.Clabe && !isValidClabe(value) {
+			errors = append(errors, ErrClabeRuleFail)
+		}
+		if tags.Nss && !isValidNss(value) {
+			errors = append(errors, ErrNssRuleFail)
+		}
+		if tags.Pattern != "" {
+			expr, _ := findPattern(tags.Pattern)
+			if regex, err := compileRegex(fullMatchRegex(expr)); err != nil || !regex.MatchString(value) {
+				errors = append(errors, ErrPatternRuleFail)
+			}
+		}
+		if tags.Phone {
+			value = normalizeRule(value, tags, ErrPhoneRuleFail, &errors, func(v string) (string, bool) {
+				return normalizePhone(v, tags.PhoneCountry)
+			})
+		}
+		if len(tags.Zip) > 0 {
+			value = normalizeRule(value, tags, ErrZipRuleFail, &errors, func(v string) (string, bool) {
+				return normalizeZip(v, tags.Zip)
+			})
+		}
+		if tags.Country {
+			value = normalizeRule(value, tags, ErrCountryRuleFail, &errors, func(v string) (string, bool) {
+				return normalizeCountry(v, tags.CountryFormat)
+			})
+		}
+		if tags.Currency {
+			value = normalizeRule(value, tags, ErrCurrencyRuleFail, &errors, normalizeCurrency)
+		}
 	}
 	if len(errors) > 0 {
 		return "", errors
@@ -218,6 +617,22 @@ func parseStringRules(v string, tags fieldTags) (string, []error) {
 	return value, nil
 }
 
+/**
+ * Check the value with a normalizing rule, the normalized value is returned
+ * when the field has the "normalize" tag entry
+ */
+func normalizeRule(value string, tags fieldTags, ruleErr error, errors *[]error, normalize func(string) (string, bool)) string {
+	normalized, ok := normalize(value)
+	if !ok {
+		*errors = append(*errors, ruleErr)
+		return value
+	}
+	if tags.Normalize {
+		return normalized
+	}
+	return value
+}
+
 func parseIntRules(v string, tags fieldTags) (int64, []error) {
 	value := strings.TrimSpace(v)
 	errors := []error{}
@@ -231,8 +646,9 @@ func parseIntRules(v string, tags fieldTags) (int64, []error) {
 		errors = append(errors, ErrTagMaxLengthForbidden)
 	}
 
-	val, err := strconv.Atoi(value)
-	if err != nil {
+	number, percent, valid := normalizeNumber(value, tags.Locale)
+	val, err := strconv.Atoi(number)
+	if err != nil || percent || !valid {
 		errors = append(errors, ErrIntegerInvalid)
 	}
 	if tags.hasMin && int(tags.Min) > val {
@@ -241,6 +657,9 @@ func parseIntRules(v string, tags fieldTags) (int64, []error) {
 	if tags.hasMax && int(tags.Max) < val {
 		errors = append(errors, ErrMaxValueRuleFail)
 	}
+	if err == nil && !tags.inNumberEnum(float64(val)) {
+		errors = append(errors, ErrEnumRuleFail)
+	}
 	if len(errors) > 0 {
 		return 0, errors
 	}
@@ -260,9 +679,15 @@ func parseFloat64Rules(v string, tags fieldTags) (float64, []error) {
 		errors = append(errors, ErrTagMaxLengthForbidden)
 	}
 
-	val, err := strconv.ParseFloat(value, 64)
+	number, percent, valid := normalizeNumber(value, tags.Locale)
+	val, err := strconv.ParseFloat(number, 64)
+	if err == nil && !valid {
+		val, err = 0, ErrDecimalInvalid
+	}
 	if err != nil {
 		errors = append(errors, ErrDecimalInvalid)
+	} else if percent {
+		val = val / 100
 	}
 	if tags.hasMin && tags.Min > val {
 		errors = append(errors, ErrMinValueRuleFail)
@@ -270,6 +695,9 @@ func parseFloat64Rules(v string, tags fieldTags) (float64, []error) {
 	if tags.hasMax && tags.Max < val {
 		errors = append(errors, ErrMaxValueRuleFail)
 	}
+	if err == nil && !tags.inNumberEnum(val) {
+		errors = append(errors, ErrEnumRuleFail)
+	}
 
 	if len(errors) > 0 {
 		return 0.0, errors
@@ -277,3 +705,152 @@ func parseFloat64Rules(v string, tags fieldTags) (float64, []error) {
 
 	return val, nil
 }
+
+func parseDateRules(v string, tags fieldTags) (time.Time, []error) {
+	value := strings.TrimSpace(v)
+	errors := []error{}
+	if value == "" {
+		if tags.Required {
+			errors = append(errors, ErrRequiredValueRuleFail)
+		}
+		return time.Time{}, errors
+	}
+
+	formats := defaultDateFormats
+	if tags.Format != "" {
+		formats = []string{tags.Format}
+	}
+	for _, f := range formats {
+		if val, err := time.Parse(f, value); err == nil {
+			return val, nil
+		}
+	}
+	if !tags.serialDates {
+		return time.Time{}, append(errors, ErrDateInvalid)
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial > 0 {
+		if val, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return val, nil
+		}
+	}
+
+	return time.Time{}, append(errors, ErrDateInvalid)
+}
+
+/**
+ * Return the date as a string accepted by the field rules, empty for the zero date
+ */
+func formatDate(t time.Time, tags fieldTags) string {
+	if t.IsZero() {
+		return ""
+	}
+	if tags.Format != "" {
+		return t.Format(tags.Format)
+	}
+	return t.Format(time.RFC3339)
+}
+
+func parseTextRules(u encoding.TextUnmarshaler, v string, tags fieldTags) []error {
+	value := strings.TrimSpace(v)
+	if value == "" {
+		if tags.Required {
+			return []error{ErrRequiredValueRuleFail}
+		}
+		return nil
+	}
+	if !tags.inEnum(value) {
+		return []error{ErrEnumRuleFail}
+	}
+	if err := u.UnmarshalText([]byte(value)); err != nil {
+		return []error{ErrTextValueInvalid}
+	}
+	return nil
+}
+
+/**
+ * Values accepted as booleans besides the strconv.ParseBool ones
+ */
+var boolValues = map[string]bool{
+	"SI": true, "SÍ": true, "YES": true, "X": true,
+	"NO": false,
+}
+
+func parseBoolRules(v string, tags fieldTags) (bool, []error) {
+	value := strings.TrimSpace(v)
+	errors := []error{}
+	if value == "" {
+		if tags.Required {
+			errors = append(errors, ErrRequiredValueRuleFail)
+		}
+		return false, errors
+	}
+	if val, err := strconv.ParseBool(value); err == nil {
+		return val, nil
+	}
+	if val, exists := boolValues[strings.ToUpper(value)]; exists {
+		return val, nil
+	}
+	return false, append(errors, ErrBoolInvalid)
+}
+
+func parseDecimalRules(v string, tags fieldTags) (Decimal, []error) {
+	value := strings.TrimSpace(v)
+	errors := []error{}
+	if tags.Required && value == "" {
+		errors = append(errors, ErrRequiredValueRuleFail)
+	}
+	if tags.hasMinLength {
+		errors = append(errors, ErrTagMinLengthForbidden)
+	}
+	if tags.hasMaxLength {
+		errors = append(errors, ErrTagMaxLengthForbidden)
+	}
+
+	number, percent, valid := normalizeNumber(value, tags.Locale)
+	val, err := ParseDecimal(number)
+	if err != nil || !valid {
+		return val, append(errors, ErrDecimalInvalid)
+	}
+	if percent {
+		val = Decimal{unscaled: val.value(), scale: val.scale + 2}
+	}
+	if tags.hasScale {
+		rescaled, exact := val.Rescale(int(tags.Scale))
+		if !exact {
+			errors = append(errors, ErrScaleRuleFail)
+		}
+		val = rescaled
+	}
+	if tags.hasPrecision && val.Precision() > int(tags.Precision) {
+		errors = append(errors, ErrPrecisionRuleFail)
+	}
+	if min, err := ParseDecimal(tags.minText); tags.hasMin && err == nil && val.Cmp(min) < 0 {
+		errors = append(errors, ErrMinValueRuleFail)
+	}
+	if max, err := ParseDecimal(tags.maxText); tags.hasMax && err == nil && val.Cmp(max) > 0 {
+		errors = append(errors, ErrMaxValueRuleFail)
+	}
+	if !tags.inDecimalEnum(val) {
+		errors = append(errors, ErrEnumRuleFail)
+	}
+
+	if len(errors) > 0 {
+		return Decimal{}, errors
+	}
+	return val, nil
+}
+
+/**
+ * Check if the decimal is in the allowed values list, if any
+ */
+func (ft *fieldTags) inDecimalEnum(value Decimal) bool {
+	if len(ft.Enum) == 0 {
+		return true
+	}
+	for _, e := range ft.Enum {
+		if v, err := ParseDecimal(e); err == nil && v.Cmp(value) == 0 {
+			return true
+		}
+	}
+	return false
+}
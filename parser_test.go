@@ -25,12 +25,52 @@ func TestTagsParserOptionsErrors(t *testing.T) {
 		{`excelLayout:" column: "`, fieldTags{}, ErrTagMissingColumnValue},
 		{`excelLayout:"cOlumN:A"`, fieldTags{}, nil},
 
+		// Header field tests
+		{`excelLayout:"header"`, fieldTags{}, ErrTagMissingHeaderValue},
+		{`excelLayout:"header: "`, fieldTags{}, ErrTagMissingHeaderValue},
+		{`excelLayout:"hEaDer:Total"`, fieldTags{}, nil},
+
+		// Enum field tests
+		{`excelLayout:"enum"`, fieldTags{}, ErrTagMissingEnumValue},
+		{`excelLayout:"enum: "`, fieldTags{}, ErrTagMissingEnumValue},
+		{`excelLayout:"enum:a|b"`, fieldTags{}, nil},
+
+		// Transform field tests
+		{`excelLayout:"trimChars"`, fieldTags{}, ErrTagMissingTrimCharsValue},
+		{`excelLayout:"replace:a"`, fieldTags{}, ErrTagInvalidReplaceValue},
+		{`excelLayout:"replace:=>b"`, fieldTags{}, ErrTagInvalidReplaceValue},
+		{`excelLayout:"upper,trimChars:$,replace:a=>"`, fieldTags{}, nil},
+
+		// Default and format field tests
+		{`excelLayout:"default"`, fieldTags{}, ErrTagMissingDefaultValue},
+		{`excelLayout:"default: "`, fieldTags{}, ErrTagMissingDefaultValue},
+		{`excelLayout:"format"`, fieldTags{}, ErrTagMissingFormatValue},
+		{`excelLayout:"default:0,format:02/01/2006"`, fieldTags{}, nil},
+
+		// Locale field tests
+		{`excelLayout:"locale:xx"`, fieldTags{}, ErrInvalidLocale},
+		{`excelLayout:"locale:es-MX"`, fieldTags{}, nil},
+
+		// Scale and precision field tests
+		{`excelLayout:"scale"`, fieldTags{}, ErrTagMissingScaleValue},
+		{`excelLayout:"precision"`, fieldTags{}, ErrTagMissingPrecisionValue},
+		{`excelLayout:"precision:2,scale:4"`, fieldTags{}, ErrTagInvalidPrecisionScaleValues},
+		{`excelLayout:"precision:10,scale:2"`, fieldTags{}, nil},
+
 		// Regex field tests
 		{`excelLayout:"regex"`, fieldTags{}, ErrTagMissingRegexValue},
 		{`excelLayout:"regex:"`, fieldTags{}, ErrTagMissingRegexValue},
 		{`excelLayout:"regex: "`, fieldTags{}, ErrTagMissingRegexValue},
 		{`excelLayout:" regex: "`, fieldTags{}, ErrTagMissingRegexValue},
 		{`excelLayout:"rEgEx:ASDF"`, fieldTags{}, nil},
+		{`excelLayout:"regex:'\d{2,4}',fullmatch"`, fieldTags{}, nil},
+		{`excelLayout:"regex:'(,'"`, fieldTags{}, ErrRegexInvalid},
+		{`excelLayout:"regex:'\d{2,4},fullmatch"`, fieldTags{}, ErrTagUnclosedQuote},
+
+		// Pattern field tests
+		{`excelLayout:"pattern"`, fieldTags{}, ErrTagMissingPatternValue},
+		{`excelLayout:"pattern:unknown"`, fieldTags{}, ErrTagUnknownPattern},
+		{`excelLayout:"pattern:rfc"`, fieldTags{}, nil},
 
 		// Min field tests
 		{`excelLayout:"min"`, fieldTags{}, ErrTagMissingMinValue},
@@ -94,6 +134,16 @@ func TestTagsParserOptionsValues(t *testing.T) {
 			},
 			ErrRegexInvalid,
 		},
+		{
+			`excelLayout:"column:a,header:Customer's name,required"`,
+			fieldTags{Column: "A", Header: "Customer's name", Required: true},
+			nil,
+		},
+		{
+			`excelLayout:"column:b,default:O'Brien,required,min:1"`,
+			fieldTags{Column: "B", Required: true, Min: 1},
+			nil,
+		},
 	}
 
 	for i, test := range tests {
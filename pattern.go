@@ -0,0 +1,131 @@
+package Layouts
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var ErrTagUnknownPattern error = errors.New("unknown pattern name for \"pattern\" tag entry")
+var ErrTagMissingPatternValue error = errors.New("expected value for \"pattern\" tag entry")
+var ErrPatternRuleFail error = errors.New("named pattern matching rule fail")
+var ErrTagUnclosedQuote error = errors.New("unclosed single quote on tag value")
+
+/**
+ * Named patterns available for the "pattern" tag entry, matched against the whole value
+ */
+var patterns = map[string]string{
+	"rfc":      `[A-ZÑ&]{3,4}\d{2}(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])[A-Z\d]{2}[A\d]`,
+	"curp":     `[A-Z][AEIOUX][A-Z]{2}\d{2}(0[1-9]|1[0-2])(0[1-9]|[12]\d|3[01])[HMX](AS|BC|BS|CC|CL|CM|CS|CH|DF|DG|GT|GR|HG|JC|MC|MN|MS|NT|NL|OC|PL|QT|QR|SP|SL|SR|TC|TS|TL|VZ|YN|ZS|NE)[B-DF-HJ-NP-TV-Z]{3}[A-Z\d]\d`,
+	"zip_mx":   `\d{5}`,
+	"zip_us":   `\d{5}(-\d{4})?`,
+	"phone_mx": `\d{10}`,
+}
+var patternsMutex sync.RWMutex
+
+/**
+ * Compiled regular expressions cache
+ */
+var regexCache sync.Map
+
+/**
+ * Register a named pattern to be used with the "pattern" tag entry, an
+ * existing pattern with the same name is replaced
+ */
+func RegisterPattern(name string, expr string) error {
+	if _, err := regexp.Compile(expr); err != nil {
+		return ErrRegexInvalid
+	}
+	patternsMutex.Lock()
+	defer patternsMutex.Unlock()
+	patterns[strings.ToLower(strings.TrimSpace(name))] = expr
+	return nil
+}
+
+/**
+ * Return the regular expression of a named pattern
+ */
+func findPattern(name string) (string, bool) {
+	patternsMutex.RLock()
+	defer patternsMutex.RUnlock()
+	expr, exists := patterns[strings.ToLower(name)]
+	return expr, exists
+}
+
+/**
+ * Return the regular expression anchored to match the whole value
+ */
+func fullMatchRegex(expr string) string {
+	return `^(?:` + expr + `)$`
+}
+
+/**
+ * Compile a regular expression reusing the previous compilations
+ */
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if r, ok := regexCache.Load(expr); ok {
+		return r.(*regexp.Regexp), nil
+	}
+	r, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(expr, r)
+	return r, nil
+}
+
+/**
+ * Split the tag options by comma, commas inside single quoted values are kept.
+ * A value is quoted only when the quote follows the "key:" separator, so the
+ * quotes inside plain values like "header:Customer's name" are literal
+ */
+func splitOptions(tags string) ([]string, error) {
+	options := []string{}
+	quoted := false
+	valueStart := false
+	hasKey := false
+	start := 0
+	for i := 0; i < len(tags); i++ {
+		c := tags[i]
+		switch {
+		case quoted:
+			if c == '\'' {
+				if i+1 < len(tags) && tags[i+1] == '\'' {
+					i++
+					continue
+				}
+				quoted = false
+			}
+		case c == ',':
+			options = append(options, tags[start:i])
+			start = i + 1
+			hasKey = false
+			valueStart = false
+		case c == ':' && !hasKey:
+			hasKey = true
+			valueStart = true
+		case c == ' ' && valueStart:
+		case c == '\'' && valueStart:
+			quoted = true
+			valueStart = false
+		default:
+			valueStart = false
+		}
+	}
+	if quoted {
+		return nil, ErrTagUnclosedQuote
+	}
+	return append(options, tags[start:]), nil
+}
+
+/**
+ * Remove the single quotes of a tag value, two single quotes inside a quoted
+ * value are read as one
+ */
+func unquoteValue(val string) string {
+	if len(val) < 2 || !strings.HasPrefix(val, "'") || !strings.HasSuffix(val, "'") {
+		return val
+	}
+	return strings.ReplaceAll(val[1:len(val)-1], "''", "'")
+}
@@ -0,0 +1,83 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+type splitOptionsTests struct {
+	input    string
+	expected []string
+}
+
+func TestSplitOptions(t *testing.T) {
+
+	tests := []splitOptionsTests{
+		{"column:A,required", []string{"column:A", "required"}},
+		{`column:A,regex:'\d{2,4}',fullmatch`, []string{"column:A", `regex:'\d{2,4}'`, "fullmatch"}},
+		{"default:'a,b',replace:'it''s=>it is'", []string{"default:'a,b'", "replace:'it''s=>it is'"}},
+		{"header:Customer's name,required", []string{"header:Customer's name", "required"}},
+		{"default:O'Brien,required,min:1", []string{"default:O'Brien", "required", "min:1"}},
+		{"default: 'a, b' ,required", []string{"default: 'a, b' ", "required"}},
+	}
+
+	for i, test := range tests {
+		if options, err := splitOptions(test.input); err != nil || !reflect.DeepEqual(options, test.expected) {
+			t.Errorf("Test %d: Expected %q, Recived: %q", i, test.expected, options)
+		}
+	}
+
+	if _, err := splitOptions("default:'a,b,required"); err != ErrTagUnclosedQuote {
+		t.Errorf("Test 6: Expected %v, Recived: %v", ErrTagUnclosedQuote, err)
+	}
+
+	if v := unquoteValue("'it''s'"); v != "it's" {
+		t.Errorf("Test 3: Expected \"it's\", Recived: \"%s\"", v)
+	}
+}
+
+type patternTests struct {
+	tags        string
+	input       string
+	errExpected error
+}
+
+func TestRegexRules(t *testing.T) {
+	if err := RegisterPattern("sku", `[A-Z]{3}-\d{4}`); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if err := RegisterPattern("invalid", `(`); err != ErrRegexInvalid {
+		t.Errorf("Expected ErrRegexInvalid, Recived: %v", err)
+	}
+
+	tests := []patternTests{
+		{`excelLayout:"regex:p([a-z]+)ch"`, "xxpeachxx", nil},
+		{`excelLayout:"regex:p([a-z]+)ch,fullmatch"`, "xxpeachxx", ErrRegexRuleFail},
+		{`excelLayout:"regex:p([a-z]+)ch,fullmatch"`, "peach", nil},
+		{`excelLayout:"regex:'^\d{2,4}$'"`, "12345", ErrRegexRuleFail},
+		{`excelLayout:"regex:'^\d{2,4}$'"`, "123", nil},
+		{`excelLayout:"regex:'a|b',fullMatch"`, "ab", ErrRegexRuleFail},
+		{`excelLayout:"pattern:rfc"`, "XAXX010101000", nil},
+		{`excelLayout:"pattern:RFC"`, "XAXX011301000", ErrPatternRuleFail},
+		{`excelLayout:"pattern:curp"`, "BADD110313HCMLNS09", nil},
+		{`excelLayout:"pattern:curp"`, "BADD110313HXXLNS09", ErrPatternRuleFail},
+		{`excelLayout:"pattern:zip_mx"`, "06600", nil},
+		{`excelLayout:"pattern:zip_mx"`, "066001", ErrPatternRuleFail},
+		{`excelLayout:"pattern:sku"`, "ABC-1234", nil},
+		{`excelLayout:"pattern:sku"`, "xABC-1234", ErrPatternRuleFail},
+	}
+
+	for i, test := range tests {
+		tags, err := parseOptions(test.tags)
+		if err != nil {
+			t.Errorf("Test %d: Unexpected error: %s", i, err.Error())
+			continue
+		}
+		_, errs := parseStringRules(test.input, tags)
+		if test.errExpected == nil && errs != nil {
+			t.Errorf("Test %d: Unexpected errors %v", i, errs)
+		} else if test.errExpected != nil && (len(errs) != 1 || errs[0] != test.errExpected) {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.errExpected, errs)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package Layouts
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrInvalidRange error = errors.New("invalid cell range reference")
+var ErrSheetNotFound error = errors.New("sheet referenced by range not found on file")
+
+/**
+ * Sheet area where the layout table is positioned, a zero value for the last
+ * row or column means the table extends until the end of the sheet. The first
+ * row is the header row unless the range is headless
+ */
+type cellRange struct {
+	Sheet    string
+	FirstRow int
+	FirstCol int
+	LastRow  int
+	LastCol  int
+	Headless bool
+}
+
+/**
+ * Parse a range reference like "C5:K", "C5:K20", "Hoja1!$C$5:$K$20" or "'My Sheet'!C5"
+ */
+func parseRange(ref string) (cellRange, error) {
+	r := cellRange{FirstRow: 1, FirstCol: 1}
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "=")
+
+	if i := strings.LastIndex(ref, "!"); i >= 0 {
+		r.Sheet = strings.Trim(strings.TrimSpace(ref[:i]), "'")
+		ref = ref[i+1:]
+	}
+	ref = strings.ReplaceAll(strings.TrimSpace(ref), "$", "")
+	if ref == "" {
+		return r, ErrInvalidRange
+	}
+
+	parts := strings.SplitN(ref, ":", 2)
+	col, row, err := excelize.CellNameToCoordinates(parts[0])
+	if err != nil {
+		return r, ErrInvalidRange
+	}
+	r.FirstCol, r.FirstRow = col, row
+
+	if len(parts) > 1 {
+		if col, row, err := excelize.CellNameToCoordinates(parts[1]); err == nil {
+			r.LastCol, r.LastRow = col, row
+		} else if col, err := excelize.ColumnNameToNumber(parts[1]); err == nil {
+			r.LastCol = col
+		} else {
+			return r, ErrInvalidRange
+		}
+		if (r.LastCol > 0 && r.LastCol < r.FirstCol) || (r.LastRow > 0 && r.LastRow < r.FirstRow) {
+			return r, ErrInvalidRange
+		}
+	}
+
+	return r, nil
+}
+
+/**
+ * Check if the row number is inside the range rows
+ */
+func (r *cellRange) containsRow(rowNumber int) bool {
+	return rowNumber >= r.FirstRow && (r.LastRow == 0 || rowNumber <= r.LastRow)
+}
+
+/**
+ * Return the row cells inside the range columns, so the first range column
+ * is read as column "A"
+ */
+func (r *cellRange) cells(row []string) []string {
+	if r.FirstCol-1 >= len(row) {
+		return []string{}
+	}
+	if r.LastCol > 0 && r.LastCol < len(row) {
+		row = row[:r.LastCol]
+	}
+	return row[r.FirstCol-1:]
+}
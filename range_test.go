@@ -0,0 +1,56 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+type rangeTests struct {
+	input       string
+	expected    cellRange
+	errExpected error
+}
+
+func TestRangeParser(t *testing.T) {
+
+	tests := []rangeTests{
+		{"A1", cellRange{FirstRow: 1, FirstCol: 1}, nil},
+		{"C5:K", cellRange{FirstRow: 5, FirstCol: 3, LastCol: 11}, nil},
+		{"C5:K20", cellRange{FirstRow: 5, FirstCol: 3, LastRow: 20, LastCol: 11}, nil},
+		{"Hoja1!$C$5:$K$20", cellRange{Sheet: "Hoja1", FirstRow: 5, FirstCol: 3, LastRow: 20, LastCol: 11}, nil},
+		{"='My Sheet'!B2", cellRange{Sheet: "My Sheet", FirstRow: 2, FirstCol: 2}, nil},
+		{"", cellRange{}, ErrInvalidRange},
+		{"C", cellRange{}, ErrInvalidRange},
+		{"C5:A", cellRange{}, ErrInvalidRange},
+		{"C5:K2", cellRange{}, ErrInvalidRange},
+		{"C5:5x", cellRange{}, ErrInvalidRange},
+	}
+
+	for i, test := range tests {
+		r, err := parseRange(test.input)
+		if err != test.errExpected {
+			t.Errorf("Test %d: Expected error \"%v\", Recived: \"%v\"", i, test.errExpected, err)
+		} else if err == nil && !reflect.DeepEqual(r, test.expected) {
+			t.Errorf("Test %d: Expected %+v, Recived: %+v", i, test.expected, r)
+		}
+	}
+}
+
+func TestRangeCells(t *testing.T) {
+	row := []string{"A", "B", "C", "D", "E"}
+
+	r := cellRange{FirstRow: 1, FirstCol: 2, LastCol: 4}
+	if cells := r.cells(row); !reflect.DeepEqual(cells, []string{"B", "C", "D"}) {
+		t.Errorf("Test 0: Unexpected cells %v", cells)
+	}
+
+	r = cellRange{FirstRow: 1, FirstCol: 3}
+	if cells := r.cells(row); !reflect.DeepEqual(cells, []string{"C", "D", "E"}) {
+		t.Errorf("Test 1: Unexpected cells %v", cells)
+	}
+
+	r = cellRange{FirstRow: 1, FirstCol: 7}
+	if cells := r.cells(row); len(cells) != 0 {
+		t.Errorf("Test 2: Unexpected cells %v", cells)
+	}
+}
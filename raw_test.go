@@ -0,0 +1,139 @@
+package Layouts
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type testRawRow struct {
+	Row
+	Rate   float64   `excelLayout:"column:A,required"`
+	Date   time.Time `excelLayout:"column:B,required"`
+	Active bool      `excelLayout:"column:C,required"`
+	Total  float64   `excelLayout:"column:D"`
+}
+
+/**
+ * Create a temporal xlsx file with formatted values and formulas
+ */
+func createTestRawFile(t *testing.T) string {
+	xlsx := excelize.NewFile()
+	sheet := xlsx.GetSheetName(0)
+	xlsx.SetSheetRow(sheet, "A1", &[]interface{}{"Rate", "Date", "Active", "Total"})
+	xlsx.SetSheetRow(sheet, "A2", &[]interface{}{0.125, time.Date(2022, 5, 30, 0, 0, 0, 0, time.UTC), true})
+	xlsx.SetCellFormula(sheet, "D2", "A2*2")
+	style, _ := xlsx.NewStyle(`{"number_format": 10}`)
+	xlsx.SetCellStyle(sheet, "A2", "A2", style)
+	fileName := filepath.Join(t.TempDir(), "raw.xlsx")
+	if err := xlsx.SaveAs(fileName); err != nil {
+		t.Fatalf("Unable to save test file: %s", err.Error())
+	}
+	return fileName
+}
+
+func TestExcelRawValuesRead(t *testing.T) {
+	fileName := createTestRawFile(t)
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testRawRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 0: Expected ErrValidationFail for formatted values, Recived: %v", err)
+	}
+
+	l = ExcelLayout{}
+	l.RawValues()
+	l.Formulas(FormulaEvaluate)
+	if err := l.ReadFile(testRawRow{}, fileName); err != nil {
+		t.Errorf("Test 1: Unexpected error: %v", l.GetErrors())
+	} else if row := l.GetRows()[0].(*testRawRow); row.Rate != 0.125 || !row.Active || row.Total != 0.25 ||
+		!row.Date.Equal(time.Date(2022, 5, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Test 1: Unexpected row values %+v", row)
+	}
+
+	l = ExcelLayout{}
+	l.RawValues()
+	l.Formulas(FormulaReject)
+	if err := l.ReadFile(testRawRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 2: Expected ErrValidationFail, Recived: %v", err)
+	} else if errs := l.GetErrors(); len(errs) != 1 || errs[0].Error != ErrFormulaRejected || errs[0].Column != "D" {
+		t.Errorf("Test 2: Expected formula rejected on column D, Recived: %v", errs)
+	}
+}
+
+type testRawFormatRow struct {
+	Row
+	Rate float64   `excelLayout:"column:A,required"`
+	Date time.Time `excelLayout:"column:B,required,format:02/01/2006"`
+}
+
+func TestExcelRawValuesLocale(t *testing.T) {
+	fileName := createTestRawFile(t)
+
+	l := ExcelLayout{}
+	l.RawValues()
+	l.Locale("es")
+	if err := l.ReadFile(testRawFormatRow{}, fileName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	expected := []interface{}{
+		&testRawFormatRow{Row: Row{Index: 2}, Rate: 0.125, Date: time.Date(2022, 5, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	if rows := l.GetRows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, rows)
+	}
+}
+
+type boolTests struct {
+	input       string
+	expected    bool
+	errExpected error
+}
+
+func TestBoolRules(t *testing.T) {
+
+	tests := []boolTests{
+		{"TRUE", true, nil},
+		{"0", false, nil},
+		{"Sí", true, nil},
+		{"no", false, nil},
+		{"maybe", false, ErrBoolInvalid},
+	}
+
+	for i, test := range tests {
+		val, errs := parseBoolRules(test.input, fieldTags{})
+		if test.errExpected != nil && (len(errs) != 1 || errs[0] != test.errExpected) {
+			t.Errorf("Test %d: Expected error %v, Recived: %v", i, test.errExpected, errs)
+		} else if test.errExpected == nil && (len(errs) > 0 || val != test.expected) {
+			t.Errorf("Test %d: Expected %v, Recived: %v %v", i, test.expected, val, errs)
+		}
+	}
+}
+
+func TestDateSerialRules(t *testing.T) {
+
+	date := time.Date(2022, 5, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input       string
+		tags        fieldTags
+		errExpected error
+	}{
+		{"44711", fieldTags{serialDates: true}, nil},
+		{"44711", fieldTags{}, ErrDateInvalid},
+		{"44711", fieldTags{serialDates: true, Format: "2006-01-02"}, nil},
+		{"44711", fieldTags{Format: "2006-01-02"}, ErrDateInvalid},
+		{"2022-05-30", fieldTags{serialDates: true, Format: "2006-01-02"}, nil},
+		{"-1", fieldTags{serialDates: true}, ErrDateInvalid},
+	}
+
+	for i, test := range tests {
+		val, errs := parseDateRules(test.input, test.tags)
+		if test.errExpected != nil && (len(errs) != 1 || errs[0] != test.errExpected) {
+			t.Errorf("Test %d: Expected error %v, Recived: %v", i, test.errExpected, errs)
+		} else if test.errExpected == nil && (len(errs) > 0 || !val.Equal(date)) {
+			t.Errorf("Test %d: Expected %v, Recived: %v %v", i, date, val, errs)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package Layouts
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrTagInvalidRefValue error = errors.New("expected \"Sheet.Column\" value for \"ref\" tag entry")
+var ErrRefSheetNotFound error = errors.New("sheet referenced by \"ref\" tag entry not found on file")
+var ErrRefColumnNotFound error = errors.New("column referenced by \"ref\" tag entry not found on sheet")
+var ErrRefCatalogNotFound error = errors.New("no values loaded for the \"ref\" tag entry")
+var ErrRefRuleFail error = errors.New("value not found on the referenced column")
+
+/**
+ * Split a reference like "Products.Code" in sheet and column, the sheet name
+ * can contain dots
+ */
+func splitRef(ref string) (string, string, bool) {
+	i := strings.LastIndex(ref, ".")
+	if i <= 0 || i == len(ref)-1 {
+		return "", "", false
+	}
+	return strings.TrimSpace(ref[:i]), strings.TrimSpace(ref[i+1:]), true
+}
+
+/**
+ * Reference key normalized for comparisons
+ */
+func refKey(ref string) string {
+	return strings.ToUpper(strings.TrimSpace(ref))
+}
+
+/**
+ * Set the values of a reference, like "Products.Code", instead of reading
+ * them from the file sheet
+ */
+func (l *ExcelLayout) Catalog(ref string, values []string) {
+	if l.refs == nil {
+		l.refs = map[string]map[string]bool{}
+	}
+	l.refs[refKey(ref)] = newCatalog(values)
+}
+
+/**
+ * Return the set of the catalog values
+ */
+func newCatalog(values []string) map[string]bool {
+	catalog := map[string]bool{}
+	for _, v := range values {
+		catalog[strings.TrimSpace(v)] = true
+	}
+	return catalog
+}
+
+/**
+ * Column letters of a reference, written in upper case like "Products.B" so
+ * a header name like "Code" is never read as a column
+ */
+var refColumnRegex = regexp.MustCompile(`^[A-Z]{1,3}$`)
+
+/**
+ * Read the values of the fields references not set as catalog, the column
+ * is found by the first row header or by column letter. The values are only
+ * kept for the file being read
+ */
+func (l *ExcelLayout) loadRefs(xlsx *excelize.File, fields []layoutField) error {
+	l.sheetRefs = map[string]map[string]bool{}
+	for _, field := range fields {
+		ref := field.Tags.Ref
+		if ref == "" {
+			continue
+		}
+		if _, loaded := l.refs[refKey(ref)]; loaded {
+			continue
+		}
+		if _, loaded := l.sheetRefs[refKey(ref)]; loaded {
+			continue
+		}
+
+		sheet, column, _ := splitRef(ref)
+		if xlsx.GetSheetIndex(sheet) == -1 {
+			return ErrRefSheetNotFound
+		}
+		rows, err := xlsx.GetRows(sheet, excelize.Options{RawCellValue: l.rawValues})
+		if err != nil {
+			return err
+		}
+
+		col := 0
+		if len(rows) > 0 {
+			for i, c := range rows[0] {
+				if headerKey(c) == headerKey(column) {
+					col = i + 1
+					break
+				}
+			}
+		}
+		if col == 0 {
+			if !refColumnRegex.MatchString(column) {
+				return ErrRefColumnNotFound
+			}
+			if col, err = excelize.ColumnNameToNumber(column); err != nil {
+				return ErrRefColumnNotFound
+			}
+		}
+
+		values := []string{}
+		for i := 1; i < len(rows); i++ {
+			if v := strings.TrimSpace(cellValue(rows[i], col)); v != "" {
+				values = append(values, v)
+			}
+		}
+		l.sheetRefs[refKey(ref)] = newCatalog(values)
+	}
+	return nil
+}
+
+/**
+ * Check the parsed field value against the referenced values, every item is
+ * checked on slices
+ */
+func (l *ExcelLayout) checkRef(f reflect.Value, tags fieldTags) []error {
+	catalog, loaded := l.refs[refKey(tags.Ref)]
+	if !loaded {
+		catalog, loaded = l.sheetRefs[refKey(tags.Ref)]
+	}
+	if !loaded {
+		return []error{ErrRefCatalogNotFound}
+	}
+	exists := func(v reflect.Value) bool {
+		value := strings.TrimSpace(formatValue(v, tags))
+		return value == "" || catalog[value]
+	}
+
+	if f.Kind() != reflect.Slice {
+		if !exists(f) {
+			return []error{ErrRefRuleFail}
+		}
+		return nil
+	}
+	errors := []error{}
+	for i := 0; i < f.Len(); i++ {
+		if !exists(f.Index(i)) {
+			errors = append(errors, &itemError{Item: i + 1, Err: ErrRefRuleFail})
+		}
+	}
+	return errors
+}
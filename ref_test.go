@@ -0,0 +1,161 @@
+package Layouts
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func createRefTestFile(t *testing.T, products ...string) string {
+	if len(products) == 0 {
+		products = []string{"A-100", "B-200"}
+	}
+	xlsx := excelize.NewFile()
+	orders := xlsx.GetSheetName(0)
+	xlsx.NewSheet("Products")
+	sheets := map[string][][]interface{}{
+		orders: {
+			{"Pedido", "Producto", "Cantidad"},
+			{"P-1", "A-100", 1},
+			{"P-2", "Z-999", 2},
+			{"P-3", "B-200", 3},
+		},
+		"Products": {{"Code", "Name"}},
+	}
+	for _, code := range products {
+		sheets["Products"] = append(sheets["Products"], []interface{}{code, "Producto " + code})
+	}
+	for sheet, rows := range sheets {
+		for i, row := range rows {
+			cell, _ := excelize.CoordinatesToCellName(1, i+1)
+			if err := xlsx.SetSheetRow(sheet, cell, &row); err != nil {
+				t.Fatalf("Unable to write test row %d: %s", i, err.Error())
+			}
+		}
+	}
+	fileName := filepath.Join(t.TempDir(), "test.xlsx")
+	if err := xlsx.SaveAs(fileName); err != nil {
+		t.Fatalf("Unable to save test file: %s", err.Error())
+	}
+	return fileName
+}
+
+type testRefOrderRow struct {
+	Row
+	ID      string `excelLayout:"column:A"`
+	Product string `excelLayout:"column:B,upper,ref:Products.Code"`
+}
+
+type testRefLetterRow struct {
+	Row
+	ID      string `excelLayout:"column:A"`
+	Product string `excelLayout:"column:B,ref:Products.A"`
+}
+
+type testRefMissingRow struct {
+	Row
+	Product string `excelLayout:"column:B,ref:Catalog.Code"`
+}
+
+type testRefMisspeltRow struct {
+	Row
+	Product string `excelLayout:"column:B,ref:Products.Cod"`
+}
+
+func TestRefFileRead(t *testing.T) {
+	fileName := createRefTestFile(t)
+	expected := []Error{{RowIndex: 3, Column: "B", Error: ErrRefRuleFail}}
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testRefOrderRow{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Test 0: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+
+	l = ExcelLayout{}
+	if err := l.ReadFile(testRefLetterRow{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expected, errs)
+	}
+
+	l = ExcelLayout{}
+	l.Catalog("Products.Code", []string{"A-100", "B-200", "Z-999"})
+	if err := l.ReadFile(testRefOrderRow{}, fileName); err != nil {
+		t.Errorf("Test 2: Unexpected error: %v %v", err, l.GetErrors())
+	}
+
+	l = ExcelLayout{}
+	if err := l.ReadFile(testRefMissingRow{}, fileName); err != ErrRefSheetNotFound {
+		t.Errorf("Test 3: Expected %v, Recived: %v", ErrRefSheetNotFound, err)
+	}
+
+	l = ExcelLayout{}
+	if err := l.ReadFile(testRefMisspeltRow{}, fileName); err != ErrRefColumnNotFound {
+		t.Errorf("Test 4: Expected %v, Recived: %v", ErrRefColumnNotFound, err)
+	}
+
+	l = ExcelLayout{}
+	l.ReadFile(testRefOrderRow{}, fileName)
+	if err := l.ReadFile(testRefOrderRow{}, createRefTestFile(t, "A-100", "B-200", "Z-999")); err != nil {
+		t.Errorf("Test 5: Unexpected error: %v %v", err, l.GetErrors())
+	}
+}
+
+type testRefListRow struct {
+	Row
+	Products []string `excelLayout:"column:A,commaSeparatedValue,ref:Products.Code"`
+	Quantity int64    `excelLayout:"column:B,ref:Quantities.Value"`
+}
+
+func TestRefCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	errs := l.ParseCells(&testRefListRow{}, []string{"A-100", "1"})
+	expected := []Error{
+		{Column: "A", Error: ErrRefCatalogNotFound},
+		{Column: "B", Error: ErrRefCatalogNotFound},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+
+	l.Catalog("products.code", []string{"A-100", "B-200"})
+	l.Catalog("Quantities.Value", []string{"1", "5", "10"})
+	errs = l.ParseCells(&testRefListRow{Row: Row{Index: 2}}, []string{"A-100,C-300,B-200", "7"})
+	expected = []Error{
+		{RowIndex: 2, Column: "A", Error: ErrRefRuleFail, Item: 2},
+		{RowIndex: 2, Column: "B", Error: ErrRefRuleFail},
+	}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expected, errs)
+	}
+
+	if errs := l.ParseStruct(&testRefListRow{Products: []string{"B-200"}, Quantity: 10}); errs != nil {
+		t.Errorf("Test 2: Unexpected errors: %v", errs)
+	}
+}
+
+func TestRefTagsParser(t *testing.T) {
+
+	tests := []struct {
+		tags     string
+		expected error
+	}{
+		{`excelLayout:"column:A,ref"`, ErrTagInvalidRefValue},
+		{`excelLayout:"column:A,ref:Products"`, ErrTagInvalidRefValue},
+		{`excelLayout:"column:A,ref:Products."`, ErrTagInvalidRefValue},
+		{`excelLayout:"column:A,ref:Catálogo 2022.Productos.Code"`, nil},
+	}
+
+	for i, test := range tests {
+		if _, err := parseOptions(test.tags); err != test.expected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.expected, err)
+		}
+	}
+}
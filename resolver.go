@@ -0,0 +1,227 @@
+package Layouts
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var ErrTagMissingExistsValue error = errors.New("expected value for \"exists\" tag entry")
+var ErrResolverNotSet error = errors.New("a resolver is required for the \"exists\" tag entries")
+var ErrResolverSourceNotFound error = errors.New("unknown resolver source")
+var ErrExistsRuleFail error = errors.New("value not found on the resolver source")
+
+/**
+ * Values checked on a single resolver call
+ */
+const resolverBatchSize = 500
+
+/**
+ * External values lookup for the "exists" tag entries, Exists returns the
+ * subset of the values found on the source, like a database table
+ */
+type Resolver interface {
+	Exists(source string, values []string) (map[string]bool, error)
+}
+
+/**
+ * Resolver with the sources values in memory
+ */
+type MemoryResolver struct {
+	sources map[string]map[string]bool
+}
+
+func NewMemoryResolver() *MemoryResolver {
+	return &MemoryResolver{sources: map[string]map[string]bool{}}
+}
+
+/**
+ * Add values to a source
+ */
+func (r *MemoryResolver) Add(source string, values ...string) {
+	if r.sources[source] == nil {
+		r.sources[source] = map[string]bool{}
+	}
+	for _, v := range values {
+		r.sources[source][v] = true
+	}
+}
+
+func (r *MemoryResolver) Exists(source string, values []string) (map[string]bool, error) {
+	s, exists := r.sources[source]
+	if !exists {
+		return nil, ErrResolverSourceNotFound
+	}
+	found := map[string]bool{}
+	for _, v := range values {
+		if s[v] {
+			found[v] = true
+		}
+	}
+	return found, nil
+}
+
+/**
+ * Value waiting for the resolver, the lookups of a file are resolved in
+ * batches once all the rows are read
+ */
+type lookup struct {
+	Error  Error
+	Source string
+	Value  string
+	detail *detailItem
+}
+
+/**
+ * Item appended to a record detail field, removed when its lookup fails
+ */
+type detailItem struct {
+	Field reflect.Value
+	Index int
+}
+
+/**
+ * Set the resolver of the "exists" tag entries, the results are cached by
+ * the layout across rows and files
+ */
+func (l *ExcelLayout) Resolver(r Resolver) {
+	l.resolver = r
+	l.resolved = map[string]map[string]bool{}
+}
+
+/**
+ * Return the lookups of the parsed field value, one by item on slices
+ */
+func fieldLookups(f reflect.Value, tags fieldTags, rowIndex int) []lookup {
+	lookups := []lookup{}
+	add := func(v reflect.Value, item int) {
+		if value := strings.TrimSpace(formatValue(v, tags)); value != "" {
+			e := Error{RowIndex: rowIndex, Column: tags.Column, Error: ErrExistsRuleFail, Item: item}
+			lookups = append(lookups, lookup{Error: e, Source: tags.Exists, Value: value})
+		}
+	}
+	if f.Kind() != reflect.Slice {
+		add(f, 0)
+		return lookups
+	}
+	for i := 0; i < f.Len(); i++ {
+		add(f.Index(i), i+1)
+	}
+	return lookups
+}
+
+/**
+ * Resolve the lookups of a single row, a resolver failure is reported as
+ * error of every lookup column
+ */
+func (l *ExcelLayout) resolveRowLookups(lookups []lookup) []Error {
+	failed, err := l.resolveLookups(lookups)
+	if err == nil {
+		return lookupErrors(failed)
+	}
+	errors := []Error{}
+	columns := map[string]bool{}
+	for _, lk := range lookups {
+		if !columns[lk.Error.Column] {
+			columns[lk.Error.Column] = true
+			errors = append(errors, Error{RowIndex: lk.Error.RowIndex, Column: lk.Error.Column, Error: err})
+		}
+	}
+	return errors
+}
+
+/**
+ * Return the errors of the failed lookups
+ */
+func lookupErrors(failed []lookup) []Error {
+	errors := []Error{}
+	for _, lk := range failed {
+		errors = append(errors, lk.Error)
+	}
+	return errors
+}
+
+/**
+ * Remove the detail items of the failed lookups from their records
+ */
+func dropDetailItems(failed []lookup) {
+	fields := map[uintptr]reflect.Value{}
+	removed := map[uintptr]map[int]bool{}
+	for _, lk := range failed {
+		if lk.detail == nil {
+			continue
+		}
+		key := lk.detail.Field.Addr().Pointer()
+		if removed[key] == nil {
+			fields[key] = lk.detail.Field
+			removed[key] = map[int]bool{}
+		}
+		removed[key][lk.detail.Index] = true
+	}
+	for key, f := range fields {
+		items := reflect.MakeSlice(f.Type(), 0, f.Len())
+		for i := 0; i < f.Len(); i++ {
+			if !removed[key][i] {
+				items = reflect.Append(items, f.Index(i))
+			}
+		}
+		f.Set(items)
+	}
+}
+
+/**
+ * Resolve the lookups not cached with a resolver call by source and batch,
+ * and return the lookups of the values not found
+ */
+func (l *ExcelLayout) resolveLookups(lookups []lookup) ([]lookup, error) {
+	if len(lookups) == 0 {
+		return nil, nil
+	}
+	if l.resolver == nil {
+		return nil, ErrResolverNotSet
+	}
+
+	pending := map[string]map[string]bool{}
+	for _, lk := range lookups {
+		if _, cached := l.resolved[lk.Source][lk.Value]; cached {
+			continue
+		}
+		if pending[lk.Source] == nil {
+			pending[lk.Source] = map[string]bool{}
+		}
+		pending[lk.Source][lk.Value] = true
+	}
+
+	for source, set := range pending {
+		values := []string{}
+		for v := range set {
+			values = append(values, v)
+		}
+		sort.Strings(values)
+		if l.resolved[source] == nil {
+			l.resolved[source] = map[string]bool{}
+		}
+		for start := 0; start < len(values); start += resolverBatchSize {
+			end := start + resolverBatchSize
+			if end > len(values) {
+				end = len(values)
+			}
+			found, err := l.resolver.Exists(source, values[start:end])
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range values[start:end] {
+				l.resolved[source][v] = found[v]
+			}
+		}
+	}
+
+	failed := []lookup{}
+	for _, lk := range lookups {
+		if !l.resolved[lk.Source][lk.Value] {
+			failed = append(failed, lk)
+		}
+	}
+	return failed, nil
+}
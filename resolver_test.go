@@ -0,0 +1,175 @@
+package Layouts
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+/**
+ * Resolver that records the values of every call
+ */
+type testCountingResolver struct {
+	*MemoryResolver
+	calls [][]string
+	err   error
+}
+
+func (r *testCountingResolver) Exists(source string, values []string) (map[string]bool, error) {
+	r.calls = append(r.calls, append([]string{source}, values...))
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.MemoryResolver.Exists(source, values)
+}
+
+type testLookupRow struct {
+	Row
+	Customer string   `excelLayout:"column:A,exists:customers"`
+	Seller   int64    `excelLayout:"column:B,exists:sellers"`
+	Products []string `excelLayout:"column:C,commaSeparatedValue,exists:products"`
+}
+
+func newTestResolver() *testCountingResolver {
+	r := &testCountingResolver{MemoryResolver: NewMemoryResolver()}
+	r.Add("customers", "C-1", "C-2")
+	r.Add("sellers", "10")
+	r.Add("products", "A", "B")
+	return r
+}
+
+func TestResolverFileRead(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Cliente", "Vendedor", "Productos"},
+		{"C-1", 10, "A,B"},
+		{"C-9", 10, "A"},
+		{"C-1", 20, "B,X"},
+		{"C-9", 10, nil},
+	})
+
+	resolver := newTestResolver()
+	l := ExcelLayout{}
+	l.Resolver(resolver)
+	if err := l.ReadFile(testLookupRow{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+
+	expected := []Error{
+		{RowIndex: 3, Column: "A", Error: ErrExistsRuleFail},
+		{RowIndex: 4, Column: "B", Error: ErrExistsRuleFail},
+		{RowIndex: 4, Column: "C", Error: ErrExistsRuleFail, Item: 2},
+		{RowIndex: 5, Column: "A", Error: ErrExistsRuleFail},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Expected %v, Recived: %v", expected, errs)
+	}
+	if len(resolver.calls) != 3 {
+		t.Errorf("Expected 3 resolver calls, Recived: %v", resolver.calls)
+	}
+	for _, call := range resolver.calls {
+		if call[0] == "customers" && !reflect.DeepEqual(call, []string{"customers", "C-1", "C-9"}) {
+			t.Errorf("Expected a single batch of customers, Recived: %v", call)
+		}
+	}
+}
+
+func TestResolverCellsParser(t *testing.T) {
+	resolver := newTestResolver()
+	l := ExcelLayout{}
+
+	errs := l.ParseCells(&testLookupRow{}, []string{"C-1"})
+	expected := []Error{{Column: "A", Error: ErrResolverNotSet}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+
+	l.Resolver(resolver)
+	if errs := l.ParseCells(&testLookupRow{}, []string{"C-1", "10", "A"}); errs != nil {
+		t.Errorf("Test 1: Unexpected errors: %v", errs)
+	}
+	if errs := l.ParseCells(&testLookupRow{}, []string{"C-2", "10", "A"}); errs != nil {
+		t.Errorf("Test 2: Unexpected errors: %v", errs)
+	}
+	if len(resolver.calls) != 4 {
+		t.Errorf("Test 2: Expected cached results, Recived calls: %v", resolver.calls)
+	}
+
+	errs = l.ParseStruct(&testLookupRow{Row: Row{Index: 3}, Customer: "C-3", Seller: 10})
+	expected = []Error{{RowIndex: 3, Column: "A", Error: ErrExistsRuleFail}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 3: Expected %v, Recived: %v", expected, errs)
+	}
+
+	failure := errors.New("connection refused")
+	l.Resolver(&testCountingResolver{MemoryResolver: NewMemoryResolver(), err: failure})
+	errs = l.ParseCells(&testLookupRow{}, []string{"C-1", "10"})
+	expected = []Error{{Column: "A", Error: failure}, {Column: "B", Error: failure}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 4: Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+func TestMemoryResolver(t *testing.T) {
+	r := NewMemoryResolver()
+	r.Add("customers", "C-1", "C-2")
+
+	found, err := r.Exists("customers", []string{"C-1", "C-3"})
+	if err != nil || !reflect.DeepEqual(found, map[string]bool{"C-1": true}) {
+		t.Errorf("Test 0: Expected %v, Recived: %v (%v)", map[string]bool{"C-1": true}, found, err)
+	}
+	if _, err := r.Exists("sellers", []string{"10"}); err != ErrResolverSourceNotFound {
+		t.Errorf("Test 1: Expected %v, Recived: %v", ErrResolverSourceNotFound, err)
+	}
+}
+
+type testLookupLine struct {
+	Row
+	Sku      string `excelLayout:"column:D,exists:products"`
+	Quantity int64  `excelLayout:"column:E,min:1"`
+}
+
+type testLookupOrder struct {
+	Row
+	ID       string           `excelLayout:"column:A,key,required"`
+	Customer string           `excelLayout:"column:B,exists:customers"`
+	Lines    []testLookupLine `excelLayout:"detail"`
+}
+
+func TestResolverDetailFileRead(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Pedido", "Cliente", "Fecha", "SKU", "Cantidad"},
+		{"P-1", "C-9", nil, "A", 1},
+		{"P-1", nil, nil, "X", 0},
+		{"P-1", nil, nil, "X", 1},
+		{"P-2", "C-1", nil, "B", 1},
+	})
+
+	l := ExcelLayout{}
+	l.Resolver(newTestResolver())
+	if err := l.ReadFile(testLookupOrder{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Test 0: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expected := []Error{
+		{RowIndex: 2, Column: "B", Error: ErrExistsRuleFail},
+		{RowIndex: 3, Column: "E", Error: ErrMinValueRuleFail, Item: 2},
+		{RowIndex: 3, Column: "D", Error: ErrExistsRuleFail, Item: 2},
+		{RowIndex: 4, Column: "D", Error: ErrExistsRuleFail, Item: 3},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+	if order := l.GetRows()[0].(*testLookupOrder); len(order.Lines) != 1 || order.Lines[0].Sku != "A" {
+		t.Errorf("Test 0: Expected only the line A, Recived: %v", order.Lines)
+	}
+
+	l = ExcelLayout{}
+	l.Resolver(newTestResolver())
+	l.MaxErrorsPerRow(1)
+	if err := l.ReadFile(testLookupOrder{}, fileName); err != ErrValidationFail {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expected = []Error{expected[0], expected[1], expected[3]}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expected) || !l.IsTruncated() {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expected, errs)
+	}
+}
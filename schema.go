@@ -0,0 +1,550 @@
+package Layouts
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * Layout column description used to generate the layout documentation, Key
+ * is the field name qualified by the groups without prefix
+ */
+type schemaField struct {
+	Name string
+	Key  string
+	Type reflect.Type
+	Tags fieldTags
+}
+
+/**
+ * JSON Schema (draft 2020-12) subset generated from the field tags
+ */
+type jsonSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Pattern     string                 `json:"pattern,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	MinLength   *int64                 `json:"minLength,omitempty"`
+	MaxLength   *int64                 `json:"maxLength,omitempty"`
+	Enum        []interface{}          `json:"enum,omitempty"`
+	Default     interface{}            `json:"default,omitempty"`
+	MinItems    *int64                 `json:"minItems,omitempty"`
+	MaxItems    *int64                 `json:"maxItems,omitempty"`
+	UniqueItems bool                   `json:"uniqueItems,omitempty"`
+	Items       *jsonSchema            `json:"items,omitempty"`
+	Properties  map[string]*jsonSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+}
+
+/**
+ * Return the layout columns of a row type
+ */
+func schemaFieldsOf(rowType interface{}) []schemaField {
+	t := reflect.TypeOf(rowType)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	fields := []schemaField{}
+	for _, f := range layoutFields(t) {
+		fields = append(fields, schemaField{Name: f.Name, Key: fieldKey(t, f), Type: t.FieldByIndex(f.Index).Type, Tags: f.Tags})
+	}
+	return fields
+}
+
+/**
+ * Return the field name qualified by the path of the nested groups without
+ * prefix, like "Home.Street", so the groups of the same type have their own
+ * keys
+ */
+func fieldKey(t reflect.Type, f layoutField) string {
+	path := []string{}
+	for _, i := range f.Index[:len(f.Index)-1] {
+		sf := t.Field(i)
+		tags, err := parseOptions(string(sf.Tag))
+		if !sf.Anonymous && (err == ErrTagNoFieldTag || tags.Prefix == "") {
+			path = append(path, sf.Name)
+		}
+		t = sf.Type
+	}
+	return strings.Join(append(path, f.Name), ".")
+}
+
+/**
+ * Return the layout columns of a runtime layout spec
+ */
+func (s *LayoutSpec) schemaFields() ([]schemaField, error) {
+	fields, err := s.fields()
+	if err != nil {
+		return nil, err
+	}
+	result := []schemaField{}
+	for i, c := range s.Columns {
+		t, _ := c.valueType()
+		result = append(result, schemaField{Name: fields[i].Name, Key: fields[i].Name, Type: t, Tags: fields[i].Tags})
+	}
+	return result, nil
+}
+
+/**
+ * Return the column reference of a field, the column letter or header name
+ */
+func (f *schemaField) column() string {
+	if f.Tags.Column != "" {
+		return f.Tags.Column
+	}
+	if len(f.Tags.Columns) > 0 {
+		return columnSpansText(f.Tags.Columns)
+	}
+	if f.Tags.Header != "" {
+		return f.Tags.Header
+	}
+	return f.Name
+}
+
+/**
+ * Return the JSON Schema of a single value
+ */
+func valueSchema(t reflect.Type, tags fieldTags) *jsonSchema {
+	s := &jsonSchema{}
+	if t == timeType {
+		s.Type = "string"
+		s.Format = "date"
+		return s
+	}
+	if t == decimalType {
+		s.Type = "string"
+		s.Pattern = `^-?\d+(\.\d+)?$`
+		if tags.hasScale && tags.Scale > 0 {
+			s.Pattern = fmt.Sprintf(`^-?\d+\.\d{%d}$`, tags.Scale)
+		} else if tags.hasScale {
+			s.Pattern = `^-?\d+$`
+		}
+		if tags.hasMin {
+			v := tags.Min
+			s.Minimum = &v
+		}
+		if tags.hasMax {
+			v := tags.Max
+			s.Maximum = &v
+		}
+		return s
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		s.Type = "string"
+		return s
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		s.Type = "array"
+		if isGroupType(t) {
+			s.Items = groupSchema(t.Elem())
+		} else {
+			s.Items = valueSchema(t.Elem(), tags)
+		}
+		if tags.hasMinItems {
+			v := tags.MinItems
+			s.MinItems = &v
+		}
+		if tags.hasMaxItems {
+			v := tags.MaxItems
+			s.MaxItems = &v
+		}
+		s.UniqueItems = tags.UniqueItems
+		return s
+	case reflect.String:
+		s.Type = "string"
+		if tags.hasMinLength {
+			v := tags.MinLength
+			s.MinLength = &v
+		}
+		if tags.hasMaxLength {
+			v := tags.MaxLength
+			s.MaxLength = &v
+		}
+		if tags.Regex != "" {
+			s.Pattern = tags.Regex
+			if tags.FullMatch {
+				s.Pattern = fullMatchRegex(tags.Regex)
+			}
+		}
+		if expr, exists := findPattern(tags.Pattern); exists && tags.Pattern != "" {
+			s.Pattern = fullMatchRegex(expr)
+		}
+		switch {
+		case tags.Rfc:
+			s.Pattern = rfcRegex.String()
+		case tags.Curp:
+			s.Pattern = curpRegex.String()
+		case tags.Clabe:
+			s.Pattern = clabeRegex.String()
+		case tags.Nss:
+			s.Pattern = nssRegex.String()
+		case tags.Phone && tags.Normalize:
+			s.Pattern = `^\+[1-9]\d{7,14}$`
+		case tags.Currency && tags.Normalize:
+			s.Pattern = `^[A-Z]{3}$`
+		}
+		if tags.Email {
+			s.Format = "email"
+		} else if tags.Url {
+			s.Format = "uri"
+		}
+		for _, e := range tags.Enum {
+			s.Enum = append(s.Enum, e)
+		}
+		return s
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		s.Type = "number"
+	case reflect.Bool:
+		s.Type = "boolean"
+		return s
+	}
+	if tags.hasMin {
+		v := tags.Min
+		s.Minimum = &v
+	}
+	if tags.hasMax {
+		v := tags.Max
+		s.Maximum = &v
+	}
+	for _, e := range tags.Enum {
+		if v, err := strconv.ParseFloat(e, 64); err == nil {
+			s.Enum = append(s.Enum, v)
+		}
+	}
+	return s
+}
+
+/**
+ * Return the JSON Schema of a repeating group item
+ */
+func groupSchema(t reflect.Type) *jsonSchema {
+	s := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}}
+	for _, f := range layoutFields(t) {
+		key := fieldKey(t, f)
+		s.Properties[key] = valueSchema(t.FieldByIndex(f.Index).Type, f.Tags)
+		if f.Tags.Required {
+			s.Required = append(s.Required, key)
+		}
+	}
+	return s
+}
+
+/**
+ * Return the field default value as it is stored on the row
+ */
+func defaultValue(t reflect.Type, tags fieldTags) interface{} {
+	v := reflect.New(t).Elem()
+	if err := parseValue(v, "", tags); err != nil {
+		return tags.Default
+	}
+	if d, ok := v.Interface().(time.Time); ok {
+		return d.Format("2006-01-02")
+	}
+	return v.Interface()
+}
+
+func buildJSONSchema(fields []schemaField, title string) ([]byte, error) {
+	schema := jsonSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Title:      title,
+		Type:       "object",
+		Properties: map[string]*jsonSchema{},
+	}
+	for _, f := range fields {
+		p := valueSchema(f.Type, f.Tags)
+		p.Description = fmt.Sprintf("Columna %s", f.column())
+		if f.Tags.hasDefault {
+			p.Default = defaultValue(f.Type, f.Tags)
+		}
+		schema.Properties[f.Key] = p
+		if f.Tags.Required {
+			schema.Required = append(schema.Required, f.Key)
+		}
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+/**
+ * Return the JSON Schema of the rows of a row type
+ */
+func JSONSchema(rowType interface{}, title string) ([]byte, error) {
+	return buildJSONSchema(schemaFieldsOf(rowType), title)
+}
+
+/**
+ * Return the JSON Schema of the rows of a runtime layout spec
+ */
+func (s *LayoutSpec) JSONSchema(title string) ([]byte, error) {
+	fields, err := s.schemaFields()
+	if err != nil {
+		return nil, err
+	}
+	return buildJSONSchema(fields, title)
+}
+
+/**
+ * Return the human readable name of a value type
+ */
+func typeDescription(t reflect.Type) string {
+	if t == timeType {
+		return "Fecha"
+	}
+	if t == decimalType {
+		return "Decimal exacto"
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		if isGroupType(t) {
+			return "Lista de grupos de columnas"
+		}
+		return fmt.Sprintf("Lista de %s separada por comas", strings.ToLower(typeDescription(t.Elem())))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "Entero"
+	case reflect.Float32, reflect.Float64:
+		return "Decimal"
+	case reflect.Bool:
+		return "Booleano"
+	}
+	return "Texto"
+}
+
+/**
+ * Return the field type description, the multi-column lists are read one
+ * item per column and the detail lists one item per row
+ */
+func (f *schemaField) typeDescription() string {
+	if f.Tags.Detail {
+		return "Detalle, un renglón por elemento"
+	}
+	if len(f.Tags.Columns) > 0 && !isGroupType(f.Type) {
+		return fmt.Sprintf("Lista de %s, un elemento por columna", strings.ToLower(typeDescription(f.Type.Elem())))
+	}
+	return typeDescription(f.Type)
+}
+
+/**
+ * Return the human readable description of the field rules
+ */
+func rulesDescription(tags fieldTags) []string {
+	rules := []string{}
+	if tags.hasMin {
+		rules = append(rules, fmt.Sprintf("Mínimo: %v", tags.Min))
+	}
+	if tags.hasMax {
+		rules = append(rules, fmt.Sprintf("Máximo: %v", tags.Max))
+	}
+	if tags.hasMinLength {
+		rules = append(rules, fmt.Sprintf("Longitud mínima: %d", tags.MinLength))
+	}
+	if tags.hasMaxLength {
+		rules = append(rules, fmt.Sprintf("Longitud máxima: %d", tags.MaxLength))
+	}
+	if tags.Separator != "" {
+		rules = append(rules, fmt.Sprintf("Separador: %q", tags.Separator))
+	}
+	if tags.hasMinItems {
+		rules = append(rules, fmt.Sprintf("Elementos mínimos: %d", tags.MinItems))
+	}
+	if tags.hasMaxItems {
+		rules = append(rules, fmt.Sprintf("Elementos máximos: %d", tags.MaxItems))
+	}
+	if tags.UniqueItems {
+		rules = append(rules, "Elementos sin repetir")
+	}
+	if tags.Email && tags.StrictEmail {
+		rules = append(rules, "Correo electrónico (solo la dirección)")
+	} else if tags.Email {
+		rules = append(rules, "Correo electrónico")
+	}
+	if tags.Url {
+		rules = append(rules, urlDescription(tags))
+	}
+	if len(tags.Domains) > 0 {
+		rules = append(rules, fmt.Sprintf("Dominios permitidos: %s", strings.Join(tags.Domains, ", ")))
+	}
+	if len(tags.DenyDomains) > 0 {
+		rules = append(rules, fmt.Sprintf("Dominios no permitidos: %s", strings.Join(tags.DenyDomains, ", ")))
+	}
+	if tags.Regex != "" && tags.FullMatch {
+		rules = append(rules, fmt.Sprintf("Expresión regular (valor completo): %s", tags.Regex))
+	} else if tags.Regex != "" {
+		rules = append(rules, fmt.Sprintf("Expresión regular: %s", tags.Regex))
+	}
+	if tags.Pattern != "" {
+		rules = append(rules, fmt.Sprintf("Formato: %s", strings.ToUpper(tags.Pattern)))
+	}
+	if tags.Rfc {
+		rules = append(rules, "RFC con dígito verificador")
+	}
+	if tags.Curp {
+		rules = append(rules, "CURP con dígito verificador")
+	}
+	if tags.Clabe {
+		rules = append(rules, "CLABE interbancaria con dígito de control")
+	}
+	if tags.Nss {
+		rules = append(rules, "NSS con dígito verificador")
+	}
+	if tags.Phone && tags.PhoneCountry != "" {
+		rules = append(rules, fmt.Sprintf("Teléfono (país por defecto: %s)", tags.PhoneCountry))
+	} else if tags.Phone {
+		rules = append(rules, "Teléfono con clave internacional")
+	}
+	if len(tags.Zip) > 0 {
+		rules = append(rules, fmt.Sprintf("Código postal: %s", strings.Join(tags.Zip, ", ")))
+	}
+	if tags.Country {
+		rules = append(rules, "Código de país ISO 3166")
+	}
+	if tags.Currency {
+		rules = append(rules, "Código de moneda ISO 4217")
+	}
+	if len(tags.Enum) > 0 {
+		rules = append(rules, fmt.Sprintf("Valores permitidos: %s", strings.Join(tags.Enum, ", ")))
+	}
+	if tags.hasScale {
+		rules = append(rules, fmt.Sprintf("Decimales: %d", tags.Scale))
+	}
+	if tags.hasPrecision {
+		rules = append(rules, fmt.Sprintf("Dígitos: %d", tags.Precision))
+	}
+	if tags.Format != "" {
+		rules = append(rules, fmt.Sprintf("Formato: %s", tags.Format))
+	}
+	if tags.Locale != "" {
+		rules = append(rules, fmt.Sprintf("Formato numérico: %s", tags.Locale))
+	}
+	if tags.Unique {
+		rules = append(rules, "Único por archivo")
+	}
+	if tags.Ref != "" {
+		rules = append(rules, fmt.Sprintf("Debe existir en: %s", tags.Ref))
+	}
+	if tags.Exists != "" {
+		rules = append(rules, fmt.Sprintf("Debe existir en el sistema: %s", tags.Exists))
+	}
+	if tags.hasDefault {
+		rules = append(rules, fmt.Sprintf("Valor por defecto: %s", tags.Default))
+	}
+	return rules
+}
+
+/**
+ * Return the URL rule description with the strict mode options
+ */
+func urlDescription(tags fieldTags) string {
+	schemes := tags.Schemes
+	if tags.StrictUrl && len(schemes) == 0 {
+		schemes = defaultUrlSchemes
+	}
+	options := []string{}
+	if len(schemes) > 0 {
+		options = append(options, fmt.Sprintf("esquemas: %s", strings.Join(schemes, ", ")))
+	}
+	if tags.StrictUrl || tags.RequireHost {
+		options = append(options, "con dominio")
+	}
+	if len(options) == 0 {
+		return "URL"
+	}
+	return fmt.Sprintf("URL (%s)", strings.Join(options, ", "))
+}
+
+var specHeaders = []string{"Columna", "Campo", "Tipo", "Requerido", "Reglas"}
+
+/**
+ * Return the documentation table rows of the fields
+ */
+func specRows(fields []schemaField) [][]string {
+	rows := [][]string{}
+	for _, f := range fields {
+		required := "No"
+		if f.Tags.Required {
+			required = "Sí"
+		}
+		rows = append(rows, []string{
+			f.column(), f.Key, f.typeDescription(), required, strings.Join(rulesDescription(f.Tags), "; "),
+		})
+	}
+	return rows
+}
+
+func buildMarkdownSpec(fields []schemaField) string {
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+	b := strings.Builder{}
+	b.WriteString("| " + strings.Join(specHeaders, " | ") + " |\n")
+	b.WriteString(strings.Repeat("| --- ", len(specHeaders)) + "|\n")
+	for _, row := range specRows(fields) {
+		for i, c := range row {
+			row[i] = escape.Replace(c)
+		}
+		b.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+func buildHTMLSpec(fields []schemaField) string {
+	b := strings.Builder{}
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for _, h := range specHeaders {
+		b.WriteString("<th>" + html.EscapeString(h) + "</th>")
+	}
+	b.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range specRows(fields) {
+		b.WriteString("<tr>")
+		for _, c := range row {
+			b.WriteString("<td>" + html.EscapeString(c) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
+
+/**
+ * Return a Markdown table describing the columns and rules of a row type
+ */
+func MarkdownSpec(rowType interface{}) string {
+	return buildMarkdownSpec(schemaFieldsOf(rowType))
+}
+
+/**
+ * Return an HTML table describing the columns and rules of a row type
+ */
+func HTMLSpec(rowType interface{}) string {
+	return buildHTMLSpec(schemaFieldsOf(rowType))
+}
+
+/**
+ * Return a Markdown table describing the columns and rules of a runtime layout spec
+ */
+func (s *LayoutSpec) MarkdownSpec() (string, error) {
+	fields, err := s.schemaFields()
+	if err != nil {
+		return "", err
+	}
+	return buildMarkdownSpec(fields), nil
+}
+
+/**
+ * Return an HTML table describing the columns and rules of a runtime layout spec
+ */
+func (s *LayoutSpec) HTMLSpec() (string, error) {
+	fields, err := s.schemaFields()
+	if err != nil {
+		return "", err
+	}
+	return buildHTMLSpec(fields), nil
+}
@@ -0,0 +1,107 @@
+package Layouts
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testSchemaRow struct {
+	Row
+	ID     int      `excelLayout:"column:A,required,min:1,enum:1|2|3"`
+	Email  string   `excelLayout:"column:B,required,email"`
+	Site   string   `excelLayout:"column:C,url,maxLength:50"`
+	Status string   `excelLayout:"column:D,enum:active|inactive"`
+	Tags   []string `excelLayout:"column:E,commaSeparatedValue,regex:^[a-z]+$"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	b, err := JSONSchema(testSchemaRow{}, "Customers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	schema := map[string]interface{}{}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("Invalid JSON schema: %s", err.Error())
+	}
+
+	if !reflect.DeepEqual(schema["required"], []interface{}{"ID", "Email"}) {
+		t.Errorf("Test 0: Unexpected required list %v", schema["required"])
+	}
+
+	properties := schema["properties"].(map[string]interface{})
+	expected := map[string]interface{}{
+		"ID":     map[string]interface{}{"description": "Columna A", "type": "integer", "minimum": 1.0, "enum": []interface{}{1.0, 2.0, 3.0}},
+		"Email":  map[string]interface{}{"description": "Columna B", "type": "string", "format": "email"},
+		"Site":   map[string]interface{}{"description": "Columna C", "type": "string", "format": "uri", "maxLength": 50.0},
+		"Status": map[string]interface{}{"description": "Columna D", "type": "string", "enum": []interface{}{"active", "inactive"}},
+		"Tags": map[string]interface{}{"description": "Columna E", "type": "array", "items": map[string]interface{}{
+			"type": "string", "pattern": "^[a-z]+$",
+		}},
+	}
+	for name, e := range expected {
+		if !reflect.DeepEqual(properties[name], e) {
+			t.Errorf("Test 1: Expected %v for property %s, Recived: %v", e, name, properties[name])
+		}
+	}
+}
+
+type testSchemaGroupRow struct {
+	testCustomerRow
+	Amount Decimal `excelLayout:"column:F,min:0,max:1000.5,scale:2"`
+}
+
+func TestJSONSchemaGroups(t *testing.T) {
+	b, err := JSONSchema(testSchemaGroupRow{}, "Customers")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	schema := map[string]interface{}{}
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("Invalid JSON schema: %s", err.Error())
+	}
+
+	expected := []interface{}{"Name", "Home.Street", "Home.City", "Work.Street", "Work.City"}
+	if !reflect.DeepEqual(schema["required"], expected) {
+		t.Errorf("Test 0: Expected required list %v, Recived: %v", expected, schema["required"])
+	}
+	properties := schema["properties"].(map[string]interface{})
+	for _, name := range []string{"Email", "CreatedBy", "Home.Street", "Work.Street", "ShippingZip"} {
+		if _, exists := properties[name]; !exists {
+			t.Errorf("Test 1: Expected property %s, Recived: %v", name, properties)
+		}
+	}
+	if p := properties["Work.City"].(map[string]interface{}); p["description"] != "Columna E" {
+		t.Errorf("Test 2: Expected the Work.City column E, Recived: %v", p)
+	}
+	amount := map[string]interface{}{
+		"description": "Columna F", "type": "string", "pattern": `^-?\d+\.\d{2}$`, "minimum": 0.0, "maximum": 1000.5,
+	}
+	if !reflect.DeepEqual(properties["Amount"], amount) {
+		t.Errorf("Test 3: Expected %v, Recived: %v", amount, properties["Amount"])
+	}
+}
+
+func TestMarkdownSpec(t *testing.T) {
+	md := MarkdownSpec(testSchemaRow{})
+	lines := strings.Split(strings.TrimSpace(md), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected 7 lines, Recived: %d", len(lines))
+	}
+	if lines[0] != "| Columna | Campo | Tipo | Requerido | Reglas |" {
+		t.Errorf("Test 0: Unexpected header %s", lines[0])
+	}
+	if lines[2] != "| A | ID | Entero | Sí | Mínimo: 1; Valores permitidos: 1, 2, 3 |" {
+		t.Errorf("Test 1: Unexpected row %s", lines[2])
+	}
+	if lines[6] != "| E | Tags | Lista de texto separada por comas | No | Expresión regular: ^[a-z]+$ |" {
+		t.Errorf("Test 2: Unexpected row %s", lines[6])
+	}
+
+	html := HTMLSpec(testSchemaRow{})
+	if !strings.Contains(html, "<td>B</td><td>Email</td><td>Texto</td><td>Sí</td><td>Correo electrónico</td>") {
+		t.Errorf("Test 3: Unexpected HTML %s", html)
+	}
+}
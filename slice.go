@@ -0,0 +1,196 @@
+package Layouts
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var ErrTagMissingSeparatorValue error = errors.New("expected value for \"separator\" tag entry")
+var ErrTagMissingMinItemsValue error = errors.New("expected value for \"minItems\" tag entry")
+var ErrTagMissingMaxItemsValue error = errors.New("expected value for \"maxItems\" tag entry")
+var ErrTagInvalidMaxMinItemsValues error = errors.New("the \"maxItems\" value should be greater than \"minItems\" value tag entry")
+var ErrMinItemsRuleFail error = errors.New("min items rule fail")
+var ErrMaxItemsRuleFail error = errors.New("max items rule fail")
+var ErrUniqueItemsRuleFail error = errors.New("duplicated item on list")
+var ErrQuotedItemInvalid error = errors.New("unterminated quoted item on list")
+
+/**
+ * Separator names allowed on the "separator" tag entry for the characters
+ * that can not be written on a field tag
+ */
+var separatorNames = map[string]string{
+	"tab":     "\t",
+	"newline": "\n",
+	"space":   " ",
+}
+
+/**
+ * Rule error of a list item, the item position starts at 1
+ */
+type itemError struct {
+	Item int
+	Err  error
+}
+
+func (e *itemError) Error() string {
+	return fmt.Sprintf("item %d: %s", e.Item, e.Err)
+}
+
+/**
+ * Return the field list separator, "," by default
+ */
+func (ft *fieldTags) separator() string {
+	if ft.Separator == "" {
+		return ","
+	}
+	return ft.Separator
+}
+
+/**
+ * Split the cell value in items, an item enclosed in double quotes can
+ * contain the separator and a double quote is escaped as ""
+ */
+func splitItems(value string, separator string) ([]string, error) {
+	items := []string{}
+	for {
+		item := strings.TrimLeft(value, " ")
+		if !strings.HasPrefix(item, `"`) {
+			i := strings.Index(value, separator)
+			if i < 0 {
+				return append(items, value), nil
+			}
+			items = append(items, value[:i])
+			value = value[i+len(separator):]
+			continue
+		}
+
+		quoted := strings.Builder{}
+		rest := item[1:]
+		for {
+			i := strings.Index(rest, `"`)
+			if i < 0 {
+				return nil, ErrQuotedItemInvalid
+			}
+			quoted.WriteString(rest[:i])
+			rest = rest[i+1:]
+			if !strings.HasPrefix(rest, `"`) {
+				break
+			}
+			quoted.WriteString(`"`)
+			rest = rest[1:]
+		}
+		items = append(items, quoted.String())
+
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			return items, nil
+		}
+		if !strings.HasPrefix(rest, separator) {
+			return nil, ErrQuotedItemInvalid
+		}
+		value = rest[len(separator):]
+	}
+}
+
+/**
+ * Split the cell value and parse the items into the slice field
+ */
+func parseSliceValue(f reflect.Value, value string, tags fieldTags) []error {
+	if !tags.CommaSeparatedValue {
+		return []error{ErrCommaSeparatedInvalid}
+	}
+	items, err := splitItems(value, tags.separator())
+	if err != nil {
+		return []error{err}
+	}
+	return parseItems(f, items, tags)
+}
+
+/**
+ * Parse the item values into the slice field, the items rule errors are
+ * returned as itemError with the item position
+ */
+func parseItems(f reflect.Value, items []string, tags fieldTags) []error {
+	return parseLocaleItems(f, items, tags, nil)
+}
+
+/**
+ * Parse the item values into the slice field, the items marked as plain are
+ * read without the field locale
+ */
+func parseLocaleItems(f reflect.Value, items []string, tags fieldTags, plain []bool) []error {
+	errors := []error{}
+	seen := map[string]int{}
+	count := 0
+	for i, v := range items {
+		if tags.DropEmpty && strings.TrimSpace(v) == "" {
+			continue
+		}
+		count++
+		itemTags := tags
+		itemTags.hasDefault = false
+		if i < len(plain) && plain[i] {
+			itemTags.Locale = ""
+		}
+		item := reflect.New(f.Type().Elem()).Elem()
+		if err := parseValue(item, v, itemTags); err != nil {
+			for _, e := range err {
+				errors = append(errors, &itemError{Item: i + 1, Err: e})
+			}
+			continue
+		}
+		if tags.UniqueItems {
+			key := fmt.Sprintf("%v", item.Interface())
+			if _, exists := seen[key]; exists {
+				errors = append(errors, &itemError{Item: i + 1, Err: ErrUniqueItemsRuleFail})
+				continue
+			}
+			seen[key] = i
+		}
+		f.Set(reflect.Append(f, item))
+	}
+	if tags.hasMinItems && count < int(tags.MinItems) {
+		errors = append(errors, ErrMinItemsRuleFail)
+	}
+	if tags.hasMaxItems && count > int(tags.MaxItems) {
+		errors = append(errors, ErrMaxItemsRuleFail)
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+/**
+ * Return the field value as it is written on a cell
+ */
+func formatValue(f reflect.Value, tags fieldTags) string {
+	if t, ok := f.Interface().(time.Time); ok {
+		return formatDate(t, tags)
+	}
+	if f.Kind() == reflect.Slice {
+		return formatItems(f, tags)
+	}
+	return fmt.Sprintf("%v", f)
+}
+
+/**
+ * Return the slice items joined by the field separator, quoting the items
+ * that contain the separator
+ */
+func formatItems(f reflect.Value, tags fieldTags) string {
+	separator := tags.separator()
+	items := []string{}
+	for i := 0; i < f.Len(); i++ {
+		item := formatValue(f.Index(i), tags)
+		if strings.Contains(item, separator) || strings.HasPrefix(strings.TrimSpace(item), `"`) {
+			item = `"` + strings.ReplaceAll(item, `"`, `""`) + `"`
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, separator)
+}
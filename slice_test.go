@@ -0,0 +1,109 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitItems(t *testing.T) {
+
+	tests := []struct {
+		input     string
+		separator string
+		expected  []string
+		err       error
+	}{
+		{"a,b,c", ",", []string{"a", "b", "c"}, nil},
+		{"a;b,c", ";", []string{"a", "b,c"}, nil},
+		{`"a,b",c`, ",", []string{"a,b", "c"}, nil},
+		{` "say ""hi""" , c`, ",", []string{`say "hi"`, " c"}, nil},
+		{`5" screen,c`, ",", []string{`5" screen`, "c"}, nil},
+		{"a || b", "||", []string{"a ", " b"}, nil},
+		{"", ",", []string{""}, nil},
+		{`"a,b`, ",", nil, ErrQuotedItemInvalid},
+		{`"a"b,c`, ",", nil, ErrQuotedItemInvalid},
+	}
+
+	for i, test := range tests {
+		result, err := splitItems(test.input, test.separator)
+		if err != test.err || !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Test %d: Expected %q (%v), Recived: %q (%v)", i, test.expected, test.err, result, err)
+		}
+	}
+}
+
+type testListRow struct {
+	Row
+	Tags   []string  `excelLayout:"column:A,separator:;,dropEmpty,uniqueItems"`
+	Sizes  []int64   `excelLayout:"column:B,commaSeparatedValue,minItems:2,maxItems:3"`
+	Emails []string  `excelLayout:"column:C,email,separator:space,dropEmpty"`
+	Prices []float64 `excelLayout:"column:D,separator:'|',default:1|2"`
+}
+
+func TestListCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testListRow{Row: Row{Index: 4}}
+	if errs := l.ParseCells(&row, []string{"red; ;blue;", "1,2", "a@example.com  b@example.com", ""}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected := testListRow{
+		Row:    Row{Index: 4},
+		Tags:   []string{"red", "blue"},
+		Sizes:  []int64{1, 2},
+		Emails: []string{"a@example.com", "b@example.com"},
+		Prices: []float64{1, 2},
+	}
+	if !reflect.DeepEqual(row, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, row)
+	}
+
+	errs := l.ParseCells(&testListRow{Row: Row{Index: 5}}, []string{"red;blue;red", "1,x,3,4", "a@example.com b@", "1|2"})
+	expectedErrs := []Error{
+		{RowIndex: 5, Column: "A", Error: ErrUniqueItemsRuleFail, Item: 3},
+		{RowIndex: 5, Column: "B", Error: ErrIntegerInvalid, Item: 2},
+		{RowIndex: 5, Column: "B", Error: ErrMaxItemsRuleFail},
+		{RowIndex: 5, Column: "C", Error: ErrEmailValueRuleFail, Item: 2},
+	}
+	if !reflect.DeepEqual(errs, expectedErrs) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expectedErrs, errs)
+	}
+
+	errs = l.ParseCells(&testListRow{}, []string{"", "1", ""})
+	if len(errs) != 1 || errs[0].Error != ErrMinItemsRuleFail {
+		t.Errorf("Test 2: Expected %v, Recived: %v", ErrMinItemsRuleFail, errs)
+	}
+}
+
+func TestListStructParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testListRow{Row: Row{Index: 7}, Tags: []string{"a;b", "c"}, Sizes: []int64{1, 2, 3, 4}, Emails: []string{"a@example.com"}, Prices: []float64{1.5}}
+	errs := l.ParseStruct(&row)
+	expected := []Error{{RowIndex: 7, Column: "B", Error: ErrMaxItemsRuleFail}}
+	if !reflect.DeepEqual(errs, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, errs)
+	}
+}
+
+func TestListTagsParser(t *testing.T) {
+
+	tests := []struct {
+		tags     string
+		expected error
+	}{
+		{`excelLayout:"column:A,separator"`, ErrTagMissingSeparatorValue},
+		{`excelLayout:"column:A,minItems"`, ErrTagMissingMinItemsValue},
+		{`excelLayout:"column:A,maxItems"`, ErrTagMissingMaxItemsValue},
+		{`excelLayout:"column:A,minItems:3,maxItems:2"`, ErrTagInvalidMaxMinItemsValues},
+		{`excelLayout:"column:A,separator:tab,minItems:1,maxItems:2"`, nil},
+	}
+
+	for i, test := range tests {
+		if _, err := parseOptions(test.tags); err != test.expected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.expected, err)
+		}
+	}
+}
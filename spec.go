@@ -0,0 +1,334 @@
+package Layouts
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrSpecMissingName error = errors.New("expected name for layout spec column")
+var ErrSpecDuplicatedName error = errors.New("duplicated name for layout spec column")
+var ErrSpecReservedName error = errors.New("the \"Index\" name is reserved for the row number")
+var ErrSpecInvalidType error = errors.New("invalid type for layout spec column")
+var ErrSpecInvalidTransform error = errors.New("invalid transform for layout spec column")
+var ErrSpecMissingColumn error = errors.New("expected column or header for layout spec column")
+
+/**
+ * Value types allowed on a layout spec column
+ */
+var specTypes = map[string]reflect.Type{
+	"string":  reflect.TypeOf(""),
+	"int":     reflect.TypeOf(int64(0)),
+	"float":   reflect.TypeOf(float64(0)),
+	"date":    timeType,
+	"bool":    reflect.TypeOf(false),
+	"decimal": decimalType,
+}
+
+/**
+ * Column definition of a runtime layout, with the same rules of the
+ * "excelLayout" field tag
+ */
+type ColumnSpec struct {
+	Name                string   `json:"name" yaml:"name"`
+	Type                string   `json:"type" yaml:"type"`
+	Column              string   `json:"column,omitempty" yaml:"column,omitempty"`
+	Columns             string   `json:"columns,omitempty" yaml:"columns,omitempty"`
+	Header              string   `json:"header,omitempty" yaml:"header,omitempty"`
+	CommaSeparatedValue bool     `json:"commaSeparatedValue,omitempty" yaml:"commaSeparatedValue,omitempty"`
+	Separator           string   `json:"separator,omitempty" yaml:"separator,omitempty"`
+	MinItems            *int64   `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems            *int64   `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems         bool     `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	DropEmpty           bool     `json:"dropEmpty,omitempty" yaml:"dropEmpty,omitempty"`
+	Email               bool     `json:"email,omitempty" yaml:"email,omitempty"`
+	StrictEmail         bool     `json:"strictEmail,omitempty" yaml:"strictEmail,omitempty"`
+	Required            bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	Regex               string   `json:"regex,omitempty" yaml:"regex,omitempty"`
+	FullMatch           bool     `json:"fullMatch,omitempty" yaml:"fullMatch,omitempty"`
+	Pattern             string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Max                 *float64 `json:"max,omitempty" yaml:"max,omitempty"`
+	Min                 *float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	MaxLength           *int64   `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinLength           *int64   `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	Url                 bool     `json:"url,omitempty" yaml:"url,omitempty"`
+	StrictUrl           bool     `json:"strictUrl,omitempty" yaml:"strictUrl,omitempty"`
+	Schemes             []string `json:"schemes,omitempty" yaml:"schemes,omitempty"`
+	RequireHost         bool     `json:"requireHost,omitempty" yaml:"requireHost,omitempty"`
+	Domains             []string `json:"domains,omitempty" yaml:"domains,omitempty"`
+	DenyDomains         []string `json:"denyDomains,omitempty" yaml:"denyDomains,omitempty"`
+	Unique              bool     `json:"unique,omitempty" yaml:"unique,omitempty"`
+	Enum                []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Ref                 string   `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Exists              string   `json:"exists,omitempty" yaml:"exists,omitempty"`
+	Rfc                 bool     `json:"rfc,omitempty" yaml:"rfc,omitempty"`
+	Curp                bool     `json:"curp,omitempty" yaml:"curp,omitempty"`
+	Clabe               bool     `json:"clabe,omitempty" yaml:"clabe,omitempty"`
+	Nss                 bool     `json:"nss,omitempty" yaml:"nss,omitempty"`
+	Phone               bool     `json:"phone,omitempty" yaml:"phone,omitempty"`
+	PhoneCountry        string   `json:"phoneCountry,omitempty" yaml:"phoneCountry,omitempty"`
+	Zip                 []string `json:"zip,omitempty" yaml:"zip,omitempty"`
+	Country             bool     `json:"country,omitempty" yaml:"country,omitempty"`
+	CountryFormat       string   `json:"countryFormat,omitempty" yaml:"countryFormat,omitempty"`
+	Currency            bool     `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Normalize           bool     `json:"normalize,omitempty" yaml:"normalize,omitempty"`
+	Transforms          []string `json:"transforms,omitempty" yaml:"transforms,omitempty"`
+	Default             *string  `json:"default,omitempty" yaml:"default,omitempty"`
+	Format              string   `json:"format,omitempty" yaml:"format,omitempty"`
+	Locale              string   `json:"locale,omitempty" yaml:"locale,omitempty"`
+	Scale               *int64   `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Precision           *int64   `json:"precision,omitempty" yaml:"precision,omitempty"`
+}
+
+/**
+ * Layout defined at runtime, rows are read as maps by column name. The spec
+ * is decoded from JSON with LoadLayoutSpec or from YAML with LoadLayoutSpecYAML
+ */
+type LayoutSpec struct {
+	Columns []ColumnSpec `json:"columns" yaml:"columns"`
+}
+
+/**
+ * Decode and validate a JSON layout spec
+ */
+func LoadLayoutSpec(data []byte) (*LayoutSpec, error) {
+	spec := &LayoutSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	if _, err := spec.fields(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+/**
+ * Decode and validate a YAML layout spec
+ */
+func LoadLayoutSpecYAML(data []byte) (*LayoutSpec, error) {
+	spec := &LayoutSpec{}
+	if err := yaml.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	if _, err := spec.fields(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+/**
+ * Return the column value type
+ */
+func (c *ColumnSpec) valueType() (reflect.Type, error) {
+	t, exists := specTypes[strings.ToLower(strings.TrimSpace(c.Type))]
+	if !exists {
+		return nil, ErrSpecInvalidType
+	}
+	if c.isList() || c.Columns != "" {
+		t = reflect.SliceOf(t)
+	}
+	return t, nil
+}
+
+/**
+ * Check if the column holds a list of values, any of the list rules makes it
+ * a list as the "excelLayout" tag options do
+ */
+func (c *ColumnSpec) isList() bool {
+	return c.CommaSeparatedValue || c.Separator != "" || c.MinItems != nil || c.MaxItems != nil ||
+		c.UniqueItems || c.DropEmpty
+}
+
+/**
+ * Return the column rules as field tags
+ */
+func (c *ColumnSpec) tags() (fieldTags, error) {
+	ft := fieldTags{
+		Column:              strings.ToUpper(strings.TrimSpace(c.Column)),
+		Header:              strings.TrimSpace(c.Header),
+		CommaSeparatedValue: c.isList(),
+		Separator:           c.Separator,
+		UniqueItems:         c.UniqueItems,
+		DropEmpty:           c.DropEmpty,
+		Email:               c.Email || c.StrictEmail,
+		StrictEmail:         c.StrictEmail,
+		Required:            c.Required,
+		Regex:               c.Regex,
+		FullMatch:           c.FullMatch,
+		Pattern:             strings.ToLower(strings.TrimSpace(c.Pattern)),
+		Url:                 c.Url || c.StrictUrl || len(c.Schemes) > 0 || c.RequireHost,
+		StrictUrl:           c.StrictUrl,
+		Schemes:             splitLowerList(strings.Join(c.Schemes, "|")),
+		RequireHost:         c.RequireHost,
+		Domains:             splitLowerList(strings.Join(c.Domains, "|")),
+		DenyDomains:         splitLowerList(strings.Join(c.DenyDomains, "|")),
+		Unique:              c.Unique,
+		Enum:                c.Enum,
+		Exists:              strings.TrimSpace(c.Exists),
+		Rfc:                 c.Rfc,
+		Curp:                c.Curp,
+		Clabe:               c.Clabe,
+		Nss:                 c.Nss,
+		Phone:               c.Phone,
+		PhoneCountry:        strings.ToUpper(strings.TrimSpace(c.PhoneCountry)),
+		Country:             c.Country,
+		CountryFormat:       strings.ToLower(strings.TrimSpace(c.CountryFormat)),
+		Currency:            c.Currency,
+		Normalize:           c.Normalize,
+		Format:              c.Format,
+	}
+	if c.Ref != "" {
+		if _, _, ok := splitRef(c.Ref); !ok {
+			return ft, ErrTagInvalidRefValue
+		}
+		ft.Ref = c.Ref
+	}
+	if c.Columns != "" {
+		spans, err := parseColumnSpans(c.Columns)
+		if err != nil {
+			return ft, err
+		}
+		ft.Columns = spans
+	}
+	if c.Locale != "" {
+		if _, exists := findNumberFormat(c.Locale); !exists {
+			return ft, ErrInvalidLocale
+		}
+		ft.Locale = c.Locale
+	}
+	if name, exists := separatorNames[strings.ToLower(c.Separator)]; exists {
+		ft.Separator = name
+	}
+	if c.Default != nil {
+		ft.Default, ft.hasDefault = *c.Default, true
+	}
+	for _, z := range c.Zip {
+		ft.Zip = append(ft.Zip, strings.ToUpper(strings.TrimSpace(z)))
+	}
+	for _, t := range c.Transforms {
+		pair := strings.SplitN(t, ":", 2)
+		val := ""
+		if len(pair) > 1 {
+			val = strings.TrimSpace(pair[1])
+		}
+		tr, ok, err := parseTransform(strings.ToLower(strings.TrimSpace(pair[0])), val)
+		if !ok {
+			return ft, ErrSpecInvalidTransform
+		}
+		if err != nil {
+			return ft, err
+		}
+		ft.Transforms = append(ft.Transforms, tr)
+	}
+	if c.Max != nil {
+		ft.Max, ft.hasMax = *c.Max, true
+		ft.maxText = strconv.FormatFloat(*c.Max, 'f', -1, 64)
+	}
+	if c.Min != nil {
+		ft.Min, ft.hasMin = *c.Min, true
+		ft.minText = strconv.FormatFloat(*c.Min, 'f', -1, 64)
+	}
+	if c.Scale != nil {
+		ft.Scale, ft.hasScale = *c.Scale, true
+	}
+	if c.Precision != nil {
+		ft.Precision, ft.hasPrecision = *c.Precision, true
+	}
+	if c.MinItems != nil {
+		ft.MinItems, ft.hasMinItems = *c.MinItems, true
+	}
+	if c.MaxItems != nil {
+		ft.MaxItems, ft.hasMaxItems = *c.MaxItems, true
+	}
+	if c.MaxLength != nil {
+		ft.MaxLength, ft.hasMaxLength = *c.MaxLength, true
+	}
+	if c.MinLength != nil {
+		ft.MinLength, ft.hasMinLength = *c.MinLength, true
+	}
+	return ft, ft.validate()
+}
+
+/**
+ * Return the spec columns as layout fields
+ */
+func (s *LayoutSpec) fields() ([]layoutField, error) {
+	fields := []layoutField{}
+	names := map[string]bool{}
+
+	for _, c := range s.Columns {
+		name := strings.TrimSpace(c.Name)
+		switch {
+		case name == "":
+			return nil, ErrSpecMissingName
+		case name == "Index":
+			return nil, ErrSpecReservedName
+		case names[name]:
+			return nil, ErrSpecDuplicatedName
+		}
+		names[name] = true
+
+		if _, err := c.valueType(); err != nil {
+			return nil, err
+		}
+		tags, err := c.tags()
+		if err != nil {
+			return nil, err
+		}
+		if tags.Column == "" && tags.Header == "" && len(tags.Columns) == 0 {
+			return nil, ErrSpecMissingColumn
+		}
+		fields = append(fields, layoutField{Name: name, Tags: tags})
+	}
+
+	return fields, nil
+}
+
+/**
+ * Parse the row cells into a map by column name, the row number is stored
+ * with the "Index" key
+ */
+func (l *ExcelLayout) ParseSpecCells(spec *LayoutSpec, rowIndex int, cells []string) (map[string]interface{}, []Error) {
+	fields, err := spec.fields()
+	if err != nil {
+		return nil, []Error{{RowIndex: rowIndex, Error: err}}
+	}
+	return l.parseSpecCells(spec, fields, rowIndex, cells)
+}
+
+func (l *ExcelLayout) parseSpecCells(spec *LayoutSpec, fields []layoutField, rowIndex int, cells []string) (map[string]interface{}, []Error) {
+	values := map[string]reflect.Value{}
+	for i, c := range spec.Columns {
+		t, _ := c.valueType()
+		values[fields[i].Name] = reflect.New(t).Elem()
+	}
+
+	errs := l.parseFields(fields, rowIndex, cells, func(field layoutField) reflect.Value {
+		return values[field.Name]
+	})
+
+	row := map[string]interface{}{"Index": rowIndex}
+	for name, v := range values {
+		row[name] = v.Interface()
+	}
+	return row, errs
+}
+
+/**
+ * Read the file rows with a runtime layout, rows are stored as
+ * map[string]interface{} and can be retrieved with GetMapRows
+ */
+func (l *ExcelLayout) ReadSpecFile(spec *LayoutSpec, filePath string) error {
+	fields, err := spec.fields()
+	if err != nil {
+		return err
+	}
+
+	return l.readFile(filePath, fields, func(rowNumber int, cells []string) (interface{}, []Error) {
+		return l.parseSpecCells(spec, fields, rowNumber, cells)
+	})
+}
@@ -0,0 +1,148 @@
+package Layouts
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testSpec = `{
+	"columns": [
+		{"name": "code", "type": "string", "column": "A", "required": true, "transforms": ["upper", "replace:_=>-"]},
+		{"name": "price", "type": "float", "header": "Price", "min": 0},
+		{"name": "stock", "type": "int", "column": "C", "max": 100},
+		{"name": "tags", "type": "string", "column": "D", "commaSeparatedValue": true, "maxLength": 5}
+	]
+}`
+
+type specTests struct {
+	input       string
+	errExpected error
+}
+
+func TestLoadLayoutSpec(t *testing.T) {
+
+	tests := []specTests{
+		{testSpec, nil},
+		{`{"columns": [{"type": "string", "column": "A"}]}`, ErrSpecMissingName},
+		{`{"columns": [{"name": "a", "type": "string", "column": "A", "transforms": ["reverse"]}]}`, ErrSpecInvalidTransform},
+		{`{"columns": [{"name": "a", "type": "string", "column": "A", "transforms": ["trimChars"]}]}`, ErrTagMissingTrimCharsValue},
+		{`{"columns": [{"name": "Index", "type": "string", "column": "A"}]}`, ErrSpecReservedName},
+		{`{"columns": [{"name": "a", "type": "string", "column": "A"}, {"name": "a", "type": "int", "column": "B"}]}`, ErrSpecDuplicatedName},
+		{`{"columns": [{"name": "a", "type": "time", "column": "A"}]}`, ErrSpecInvalidType},
+		{`{"columns": [{"name": "a", "type": "string"}]}`, ErrSpecMissingColumn},
+		{`{"columns": [{"name": "a", "type": "int", "column": "A", "min": 2, "max": 1}]}`, ErrTagInvalidMaxMinValues},
+		{`{"columns": [{"name": "a", "type": "string", "column": "A", "regex": "("}]}`, ErrRegexInvalid},
+	}
+
+	for i, test := range tests {
+		if _, err := LoadLayoutSpec([]byte(test.input)); err != test.errExpected {
+			t.Errorf("Test %d: Expected error \"%v\", Recived: \"%v\"", i, test.errExpected, err)
+		}
+	}
+}
+
+const testYAMLSpec = `
+columns:
+  - name: code
+    type: string
+    column: A
+    required: true
+    transforms: [upper, "replace:_=>-"]
+  - {name: price, type: float, header: Price, min: 0}
+  - {name: stock, type: int, column: C, max: 100}
+  - {name: tags, type: string, column: D, commaSeparatedValue: true, maxLength: 5}
+`
+
+func TestLoadLayoutSpecYAML(t *testing.T) {
+	spec, err := LoadLayoutSpecYAML([]byte(testYAMLSpec))
+	if err != nil {
+		t.Fatalf("Unable to load spec: %s", err.Error())
+	}
+	expected, _ := LoadLayoutSpec([]byte(testSpec))
+	if !reflect.DeepEqual(spec, expected) {
+		t.Errorf("Expected %+v, Recived: %+v", expected, spec)
+	}
+
+	tests := []specTests{
+		{"columns:\n  - {type: string, column: A}", ErrSpecMissingName},
+		{"columns:\n  - {name: a, type: time, column: A}", ErrSpecInvalidType},
+		{"columns:\n  - {name: a, type: int, column: A, min: 2, max: 1}", ErrTagInvalidMaxMinValues},
+	}
+	for i, test := range tests {
+		if _, err := LoadLayoutSpecYAML([]byte(test.input)); err != test.errExpected {
+			t.Errorf("Test %d: Expected error \"%v\", Recived: \"%v\"", i, test.errExpected, err)
+		}
+	}
+	if _, err := LoadLayoutSpecYAML([]byte("columns: [")); err == nil {
+		t.Errorf("Expected a YAML syntax error")
+	}
+}
+
+func TestExcelSpecFileRead(t *testing.T) {
+	spec, err := LoadLayoutSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("Unable to load spec: %s", err.Error())
+	}
+
+	fileName := createTestFile(t, [][]interface{}{
+		{"Code", "Price", "Stock", "Tags"},
+		{"a_1", "10.5", "3", "red,blue"},
+		{"", "-1", "200", "yellow"},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadSpecFile(spec, fileName); err != ErrValidationFail {
+		t.Errorf("Test 0: Expected ErrValidationFail, Recived: %v", err)
+	}
+
+	rows := l.GetMapRows()
+	if len(rows) != 2 {
+		t.Fatalf("Test 1: Expected 2 rows, Recived: %d", len(rows))
+	}
+	expected := map[string]interface{}{
+		"Index": 2, "code": "A-1", "price": 10.5, "stock": int64(3), "tags": []string{"red", "blue"},
+	}
+	if !reflect.DeepEqual(rows[0], expected) {
+		t.Errorf("Test 2: Expected %v, Recived: %v", expected, rows[0])
+	}
+
+	errs := l.GetErrors()
+	if len(errs) != 4 {
+		t.Errorf("Test 3: Expected 4 errors, Recived: %d", len(errs))
+	}
+}
+
+func TestSpecListRules(t *testing.T) {
+	spec, err := LoadLayoutSpec([]byte(`{"columns": [
+		{"name": "tags", "type": "string", "column": "A", "separator": "|", "maxItems": 2},
+		{"name": "ids", "type": "int", "column": "B", "minItems": 2},
+		{"name": "codes", "type": "string", "column": "C", "separator": "space"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unable to load spec: %s", err.Error())
+	}
+
+	fileName := createTestFile(t, [][]interface{}{
+		{"Tags", "Ids", "Codes"},
+		{"a|b", "1,2", "x y"},
+		{"a|b|c", "3", "z"},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadSpecFile(spec, fileName); err != ErrValidationFail {
+		t.Fatalf("Test 0: Expected ErrValidationFail, Recived: %v", err)
+	}
+	expected := map[string]interface{}{
+		"Index": 2, "tags": []string{"a", "b"}, "ids": []int64{1, 2}, "codes": []string{"x", "y"},
+	}
+	if rows := l.GetMapRows(); len(rows) != 2 || !reflect.DeepEqual(rows[0], expected) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expected, rows)
+	}
+	expectedErrors := []Error{
+		{RowIndex: 3, Column: "A", Error: ErrMaxItemsRuleFail},
+		{RowIndex: 3, Column: "B", Error: ErrMinItemsRuleFail},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expectedErrors) {
+		t.Errorf("Test 2: Expected %v, Recived: %v", expectedErrors, errs)
+	}
+}
@@ -0,0 +1,137 @@
+package Layouts
+
+import (
+	"encoding/xml"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrTableNotFound error = errors.New("table not found on file")
+
+/**
+ * Excel table (ListObject) definition
+ */
+type excelTable struct {
+	Name           string
+	Sheet          string
+	Ref            string
+	HeaderRowCount int
+	TotalsRowCount int
+}
+
+type xmlRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xmlTable struct {
+	Name           string `xml:"name,attr"`
+	DisplayName    string `xml:"displayName,attr"`
+	Ref            string `xml:"ref,attr"`
+	HeaderRowCount string `xml:"headerRowCount,attr"`
+	TotalsRowCount string `xml:"totalsRowCount,attr"`
+}
+
+/**
+ * Return the content of a file part, empty when the part does not exist
+ */
+func readPart(xlsx *excelize.File, name string) []byte {
+	if content, ok := xlsx.Pkg.Load(strings.TrimPrefix(name, "/")); ok {
+		if b, ok := content.([]byte); ok {
+			return b
+		}
+	}
+	return nil
+}
+
+/**
+ * Read the relationships of a file part, targets are resolved as absolute paths
+ */
+func readRelationships(xlsx *excelize.File, part string) xmlRelationships {
+	rels := xmlRelationships{}
+	dir, file := path.Split(part)
+	content := readPart(xlsx, dir+"_rels/"+file+".rels")
+	if content == nil {
+		return rels
+	}
+	if err := xml.Unmarshal(content, &rels); err != nil {
+		return rels
+	}
+	for i, r := range rels.Relationships {
+		if strings.HasPrefix(r.Target, "/") {
+			rels.Relationships[i].Target = strings.TrimPrefix(r.Target, "/")
+		} else {
+			rels.Relationships[i].Target = path.Join(dir, r.Target)
+		}
+	}
+	return rels
+}
+
+/**
+ * Read all the tables defined on the file sheets
+ */
+func readTables(xlsx *excelize.File) []excelTable {
+	tables := []excelTable{}
+	if xlsx.WorkBook == nil {
+		return tables
+	}
+
+	sheets := map[string]string{}
+	for _, r := range readRelationships(xlsx, "xl/workbook.xml").Relationships {
+		sheets[r.ID] = r.Target
+	}
+
+	for _, s := range xlsx.WorkBook.Sheets.Sheet {
+		for _, r := range readRelationships(xlsx, sheets[s.ID]).Relationships {
+			if !strings.HasSuffix(r.Type, "/table") {
+				continue
+			}
+			t := xmlTable{}
+			if err := xml.Unmarshal(readPart(xlsx, r.Target), &t); err != nil {
+				continue
+			}
+			table := excelTable{Name: t.Name, Sheet: s.Name, Ref: t.Ref, HeaderRowCount: 1}
+			if table.Name == "" {
+				table.Name = t.DisplayName
+			}
+			if v, err := strconv.Atoi(t.HeaderRowCount); err == nil {
+				table.HeaderRowCount = v
+			}
+			if v, err := strconv.Atoi(t.TotalsRowCount); err == nil {
+				table.TotalsRowCount = v
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	return tables
+}
+
+/**
+ * Find a table by name and return its sheet area
+ */
+func findTableRange(xlsx *excelize.File, name string) (cellRange, error) {
+	for _, t := range readTables(xlsx) {
+		if !strings.EqualFold(t.Name, name) {
+			continue
+		}
+		r, err := parseRange(t.Ref)
+		if err != nil {
+			return r, err
+		}
+		r.Sheet = t.Sheet
+		if r.LastRow > 0 {
+			r.LastRow -= t.TotalsRowCount
+		}
+		r.Headless = t.HeaderRowCount == 0
+		return r, nil
+	}
+	return cellRange{}, ErrTableNotFound
+}
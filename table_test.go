@@ -0,0 +1,88 @@
+package Layouts
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type testSaleRow struct {
+	Row
+	Product  string  `excelLayout:"header:Product,required"`
+	Quantity int     `excelLayout:"header:Qty,min:1"`
+	Total    float64 `excelLayout:"required"`
+}
+
+/**
+ * Create a temporal xlsx file with a "Sales" table on the second sheet
+ */
+func createTestTableFile(t *testing.T) string {
+	xlsx := excelize.NewFile()
+	xlsx.NewSheet("Data")
+	xlsx.SetSheetRow("Data", "B3", &[]interface{}{"Qty", "Product", "Total"})
+	xlsx.SetSheetRow("Data", "B4", &[]interface{}{"2", "Pencil", "10.5"})
+	xlsx.SetSheetRow("Data", "B5", &[]interface{}{"1", "Eraser", "3"})
+	xlsx.SetSheetRow("Data", "B7", &[]interface{}{"Not in the table"})
+	if err := xlsx.AddTable("Data", "B3", "D5", `{"table_name":"Sales"}`); err != nil {
+		t.Fatalf("Unable to add test table: %s", err.Error())
+	}
+	fileName := filepath.Join(t.TempDir(), "table.xlsx")
+	if err := xlsx.SaveAs(fileName); err != nil {
+		t.Fatalf("Unable to save test file: %s", err.Error())
+	}
+	return fileName
+}
+
+func TestExcelTableRead(t *testing.T) {
+	fileName := createTestTableFile(t)
+
+	l := ExcelLayout{}
+	l.Table("sales")
+	if err := l.ReadFile(testSaleRow{}, fileName); err != nil {
+		t.Errorf("Test 0: Unexpected error: %s", err.Error())
+	} else if l.CountRows() != 2 {
+		t.Errorf("Test 0: Expected 2 rows, Recived: %d", l.CountRows())
+	} else if row := l.GetRows()[1].(*testSaleRow); row.Product != "Eraser" || row.Quantity != 1 || row.Total != 3 || row.Index != 5 {
+		t.Errorf("Test 0: Unexpected row values %+v", row)
+	}
+
+	l = ExcelLayout{}
+	l.Range("Sales")
+	if err := l.ReadFile(testSaleRow{}, fileName); err != nil {
+		t.Errorf("Test 1: Unexpected error: %s", err.Error())
+	} else if l.CountRows() != 2 {
+		t.Errorf("Test 1: Expected 2 rows, Recived: %d", l.CountRows())
+	}
+
+	l = ExcelLayout{}
+	l.Table("Purchases")
+	if err := l.ReadFile(testSaleRow{}, fileName); err != ErrTableNotFound {
+		t.Errorf("Test 2: Expected ErrTableNotFound, Recived: %v", err)
+	}
+}
+
+func TestExcelHeaderNotFound(t *testing.T) {
+	fileName := createTestFile(t, [][]interface{}{
+		{"Product", "Total"},
+		{"Pencil", "10.5"},
+	})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testSaleRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 0: Expected ErrValidationFail, Recived: %v", err)
+	} else if errs := l.GetErrors(); len(errs) != 1 || errs[0].Error != ErrHeaderNotFound || errs[0].Column != "Qty" {
+		t.Errorf("Test 0: Expected header \"Qty\" not found error, Recived: %v", errs)
+	}
+
+	fileName = createTestFile(t, [][]interface{}{
+		{"Product", "Qty"},
+		{"Pencil", "2"},
+	})
+	l = ExcelLayout{}
+	if err := l.ReadFile(testSaleRow{}, fileName); err != ErrValidationFail {
+		t.Errorf("Test 1: Expected ErrValidationFail, Recived: %v", err)
+	} else if errs := l.GetErrors(); len(errs) != 1 || errs[0].Error != ErrHeaderNotFound || errs[0].Column != "Total" {
+		t.Errorf("Test 1: Expected header \"Total\" not found error, Recived: %v", errs)
+	}
+}
@@ -38,6 +38,12 @@ func (pt *FiledTagsParserTests) compareTo(ft *fieldTags) (bool, []string) {
 			pt.expected.Column, ft.Column,
 		))
 	}
+	if pt.expected.Header != ft.Header {
+		errors = append(errors, fmt.Sprintf(
+			"Expected \"%v\" for field Header, recieved: \"%v\"",
+			pt.expected.Header, ft.Header,
+		))
+	}
 	if pt.expected.CommaSeparatedValue != ft.CommaSeparatedValue {
 		errors = append(errors, fmt.Sprintf(
 			"Expected \"%v\" for field CommaSeparatedValue, recieved: \"%v\"",
@@ -0,0 +1,104 @@
+package Layouts
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var ErrTagMissingTrimCharsValue error = errors.New("expected value for \"trimChars\" tag entry")
+var ErrTagInvalidReplaceValue error = errors.New("expected \"old=>new\" value for \"replace\" tag entry")
+
+/**
+ * Value transformation applied before the field rules
+ */
+type transform struct {
+	Name string
+	Arg  string
+}
+
+/**
+ * Parse a transformation tag entry, return false when the key is not a transformation
+ */
+func parseTransform(key string, val string) (transform, bool, error) {
+	t := transform{Name: key}
+	switch key {
+	case "upper", "lower", "title", "collapsespaces", "removeaccents", "digitsonly":
+	case "trimchars":
+		if val == "" {
+			return t, true, ErrTagMissingTrimCharsValue
+		}
+		t.Arg = val
+	case "replace":
+		if !strings.Contains(val, "=>") || strings.HasPrefix(val, "=>") {
+			return t, true, ErrTagInvalidReplaceValue
+		}
+		t.Arg = val
+	default:
+		return t, false, nil
+	}
+	return t, true, nil
+}
+
+/**
+ * Apply the transformations in declared order to the trimmed value
+ */
+func applyTransforms(value string, transforms []transform) string {
+	value = strings.TrimSpace(value)
+	for _, t := range transforms {
+		switch t.Name {
+		case "upper":
+			value = strings.ToUpper(value)
+		case "lower":
+			value = strings.ToLower(value)
+		case "title":
+			value = toTitle(value)
+		case "collapsespaces":
+			value = strings.Join(strings.Fields(value), " ")
+		case "removeaccents":
+			value = removeAccents(value)
+		case "digitsonly":
+			value = strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, value)
+		case "trimchars":
+			value = strings.Trim(strings.TrimSpace(value), t.Arg)
+		case "replace":
+			pair := strings.SplitN(t.Arg, "=>", 2)
+			value = strings.ReplaceAll(value, pair[0], pair[1])
+		}
+	}
+	return value
+}
+
+/**
+ * Return the value with the first letter of every word in upper case and the rest in lower case
+ */
+func toTitle(value string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		defer func() { prev = r }()
+		if unicode.IsSpace(prev) || prev == '-' {
+			return unicode.ToUpper(r)
+		}
+		return unicode.ToLower(r)
+	}, value)
+}
+
+/**
+ * Return the value without diacritical marks, "Ángel Núñez" as "Angel Nunez"
+ */
+func removeAccents(value string) string {
+	value = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(value))
+	return norm.NFC.String(value)
+}
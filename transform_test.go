@@ -0,0 +1,60 @@
+package Layouts
+
+import (
+	"testing"
+)
+
+type transformTests struct {
+	input    string
+	tags     string
+	expected string
+}
+
+func TestTransforms(t *testing.T) {
+
+	tests := []transformTests{
+		{" xaxx010101000 ", `excelLayout:"upper"`, "XAXX010101000"},
+		{"XXX@YYY.COM", `excelLayout:"lower"`, "xxx@yyy.com"},
+		{"aRTZIEL nARVAIZA-gONZÁLEZ", `excelLayout:"title"`, "Artziel Narvaiza-González"},
+		{"  Av.   Juárez    1 ", `excelLayout:"collapseSpaces"`, "Av. Juárez 1"},
+		{"Ángel Núñez Güemes", `excelLayout:"removeAccents"`, "Angel Nunez Guemes"},
+		{"(55) 1234-5678", `excelLayout:"digitsOnly"`, "5512345678"},
+		{"$1500$", `excelLayout:"trimChars:$"`, "1500"},
+		{"a-b-c", `excelLayout:"replace:-=>"`, "abc"},
+		{"a-b-c", `excelLayout:"replace:-=>/"`, "a/b/c"},
+		{" josé  pérez ", `excelLayout:"removeAccents,upper,collapseSpaces,replace:E=>3"`, "JOS3 P3R3Z"},
+	}
+
+	for i, test := range tests {
+		tags, err := parseOptions(test.tags)
+		if err != nil {
+			t.Errorf("Test %d: Unexpected error: %s", i, err.Error())
+			continue
+		}
+		if result := applyTransforms(test.input, tags.Transforms); result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\", Recived: \"%s\"", i, test.expected, result)
+		}
+	}
+}
+
+type testTransformRow struct {
+	Row
+	RFC   string   `excelLayout:"column:A,removeAccents,upper,minLength:12,maxLength:13"`
+	Phone string   `excelLayout:"column:B,digitsOnly,minLength:10,maxLength:10"`
+	Price float64  `excelLayout:"column:C,trimChars:$"`
+	Tags  []string `excelLayout:"column:D,commaSeparatedValue,lower"`
+}
+
+func TestTransformCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+	row := testTransformRow{}
+	cells := []string{"xáxx010101000", "(55) 1234-5678", "$1500.50", "Red, BLUE"}
+	if errs := l.ParseCells(&row, cells); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	if row.RFC != "XAXX010101000" || row.Phone != "5512345678" || row.Price != 1500.5 || len(row.Tags) != 2 || row.Tags[1] != "blue" {
+		t.Errorf("Unexpected row values %+v", row)
+	}
+}
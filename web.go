@@ -0,0 +1,122 @@
+package Layouts
+
+import (
+	"errors"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+var ErrTagInvalidUrlMode error = errors.New("the \"url\" tag entry value should be \"strict\"")
+var ErrTagInvalidEmailMode error = errors.New("the \"email\" tag entry value should be \"strict\"")
+var ErrTagMissingSchemesValue error = errors.New("expected value for \"schemes\" tag entry")
+var ErrTagMissingDomainsValue error = errors.New("expected value for \"domains\" tag entry")
+var ErrTagMissingDenyDomainsValue error = errors.New("expected value for \"denyDomains\" tag entry")
+var ErrTagDomainsForbidden error = errors.New("the use of \"domains\" and \"denyDomains\" tag entries requires \"url\" or \"email\"")
+var ErrDomainRuleFail error = errors.New("domain not allowed")
+
+/**
+ * Schemes allowed on strict mode URLs without "schemes" tag entry
+ */
+var defaultUrlSchemes = []string{"http", "https"}
+
+/**
+ * Split a "|" separated tag entry value in lower case items
+ */
+func splitLowerList(val string) []string {
+	items := []string{}
+	for _, item := range strings.Split(val, "|") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+/**
+ * Check the host against the domain allow and deny lists, a domain on the
+ * lists also matches its subdomains
+ */
+func domainAllowed(host string, tags fieldTags) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	matches := func(domains []string) bool {
+		for _, d := range domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(tags.Domains) > 0 && !matches(tags.Domains) {
+		return false
+	}
+	return !matches(tags.DenyDomains)
+}
+
+/**
+ * Validate an URL value, on strict mode the scheme should be on the allowed
+ * list and the host is required. Return the URL with lower case scheme and host
+ */
+func checkUrl(value string, tags fieldTags) (string, error) {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return "", ErrUrlValueRuleFail
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+
+	schemes := tags.Schemes
+	if tags.StrictUrl && len(schemes) == 0 {
+		schemes = defaultUrlSchemes
+	}
+	if len(schemes) > 0 {
+		allowed := false
+		for _, s := range schemes {
+			allowed = allowed || s == u.Scheme
+		}
+		if !allowed {
+			return "", ErrUrlValueRuleFail
+		}
+	}
+	if (tags.StrictUrl || tags.RequireHost) && u.Hostname() == "" {
+		return "", ErrUrlValueRuleFail
+	}
+	if !domainAllowed(u.Hostname(), tags) {
+		return "", ErrDomainRuleFail
+	}
+
+	return u.String(), nil
+}
+
+/**
+ * Validate an email value, on strict mode only the bare address is accepted
+ * and the domain should have at least two labels. Return the bare address
+ * with lower case domain
+ */
+func checkEmail(value string, tags fieldTags) (string, error) {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return "", ErrEmailValueRuleFail
+	}
+	at := strings.LastIndex(addr.Address, "@")
+	local, domain := addr.Address[:at], strings.ToLower(addr.Address[at+1:])
+
+	if tags.StrictEmail {
+		if addr.Name != "" || addr.Address != value {
+			return "", ErrEmailValueRuleFail
+		}
+		if !strings.Contains(domain, ".") {
+			return "", ErrEmailValueRuleFail
+		}
+		for _, label := range strings.Split(domain, ".") {
+			if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+				return "", ErrEmailValueRuleFail
+			}
+		}
+	}
+	if !domainAllowed(domain, tags) {
+		return "", ErrDomainRuleFail
+	}
+
+	return local + "@" + domain, nil
+}
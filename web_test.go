@@ -0,0 +1,112 @@
+package Layouts
+
+import (
+	"testing"
+)
+
+type webTests struct {
+	tags     string
+	input    string
+	expected string
+	err      error
+}
+
+func TestCheckUrl(t *testing.T) {
+
+	tests := []webTests{
+		{`excelLayout:"column:A,url"`, "/foo", "/foo", nil},
+		{`excelLayout:"column:A,url"`, "http:x", "http:x", nil},
+		{`excelLayout:"column:A,url:strict"`, "/foo", "", ErrUrlValueRuleFail},
+		{`excelLayout:"column:A,url:strict"`, "http:x", "", ErrUrlValueRuleFail},
+		{`excelLayout:"column:A,url:strict"`, "ftp://files.example.com", "", ErrUrlValueRuleFail},
+		{`excelLayout:"column:A,url:strict"`, "HTTPS://Example.COM/Path?q=1", "https://example.com/Path?q=1", nil},
+		{`excelLayout:"column:A,schemes:ftp|sftp"`, "ftp://files.example.com", "ftp://files.example.com", nil},
+		{`excelLayout:"column:A,schemes:ftp|sftp"`, "http://files.example.com", "", ErrUrlValueRuleFail},
+		{`excelLayout:"column:A,requirehost"`, "mailto:a@example.com", "", ErrUrlValueRuleFail},
+		{`excelLayout:"column:A,url,domains:example.com"`, "https://www.example.com", "https://www.example.com", nil},
+		{`excelLayout:"column:A,url,domains:example.com"`, "https://example.org", "", ErrDomainRuleFail},
+		{`excelLayout:"column:A,url,denyDomains:example.org"`, "https://cdn.example.org", "", ErrDomainRuleFail},
+	}
+
+	for i, test := range tests {
+		tags, err := parseOptions(test.tags)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error: %v", i, err)
+		}
+		result, err := checkUrl(test.input, tags)
+		if err != test.err || result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\" (%v), Recived: \"%s\" (%v)", i, test.expected, test.err, result, err)
+		}
+	}
+}
+
+func TestCheckEmail(t *testing.T) {
+
+	tests := []webTests{
+		{`excelLayout:"column:A,email"`, "Name <user@example.com>", "user@example.com", nil},
+		{`excelLayout:"column:A,email"`, "user@localhost", "user@localhost", nil},
+		{`excelLayout:"column:A,email:strict"`, "Name <user@example.com>", "", ErrEmailValueRuleFail},
+		{`excelLayout:"column:A,email:strict"`, "<user@example.com>", "", ErrEmailValueRuleFail},
+		{`excelLayout:"column:A,email:strict"`, "user@localhost", "", ErrEmailValueRuleFail},
+		{`excelLayout:"column:A,email:strict"`, "user@-example.com", "", ErrEmailValueRuleFail},
+		{`excelLayout:"column:A,email:strict"`, "User.Name@Example.COM", "User.Name@example.com", nil},
+		{`excelLayout:"column:A,email,domains:example.com|example.org"`, "user@mail.example.org", "user@mail.example.org", nil},
+		{`excelLayout:"column:A,email,domains:example.com|example.org"`, "user@gmail.com", "", ErrDomainRuleFail},
+		{`excelLayout:"column:A,email,denyDomains:mailinator.com"`, "user@Mailinator.com", "", ErrDomainRuleFail},
+	}
+
+	for i, test := range tests {
+		tags, err := parseOptions(test.tags)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error: %v", i, err)
+		}
+		result, err := checkEmail(test.input, tags)
+		if err != test.err || result != test.expected {
+			t.Errorf("Test %d: Expected \"%s\" (%v), Recived: \"%s\" (%v)", i, test.expected, test.err, result, err)
+		}
+	}
+}
+
+func TestWebTagsParser(t *testing.T) {
+
+	tests := []struct {
+		tags     string
+		expected error
+	}{
+		{`excelLayout:"column:A,url:loose"`, ErrTagInvalidUrlMode},
+		{`excelLayout:"column:A,email:loose"`, ErrTagInvalidEmailMode},
+		{`excelLayout:"column:A,schemes"`, ErrTagMissingSchemesValue},
+		{`excelLayout:"column:A,email,domains"`, ErrTagMissingDomainsValue},
+		{`excelLayout:"column:A,url,denyDomains"`, ErrTagMissingDenyDomainsValue},
+		{`excelLayout:"column:A,domains:example.com"`, ErrTagDomainsForbidden},
+	}
+
+	for i, test := range tests {
+		if _, err := parseOptions(test.tags); err != test.expected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.expected, err)
+		}
+	}
+}
+
+type testWebRow struct {
+	Row
+	Email    string `excelLayout:"column:A,email:strict,normalize"`
+	Contact  string `excelLayout:"column:B,email,normalize"`
+	Site     string `excelLayout:"column:C,url:strict,normalize"`
+	Original string `excelLayout:"column:D,url:strict"`
+}
+
+func TestWebCellsParser(t *testing.T) {
+	l := ExcelLayout{}
+
+	row := testWebRow{}
+	if errs := l.ParseCells(&row, []string{"user@Example.com", "Name <user@Example.com>", "HTTPS://Example.com/a", "HTTPS://Example.com/a"}); errs != nil {
+		for _, e := range errs {
+			t.Errorf("Test 0: Unexpected error: %s", ErrToMessage(&e))
+		}
+	}
+	expected := testWebRow{Email: "user@example.com", Contact: "user@example.com", Site: "https://example.com/a", Original: "HTTPS://Example.com/a"}
+	if row != expected {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, row)
+	}
+}
@@ -0,0 +1,861 @@
+package Layouts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrXlsInvalid error = errors.New("invalid or unsupported Excel 97-2003 file")
+var ErrXlsEncrypted error = errors.New("encrypted Excel 97-2003 files are not supported")
+
+/**
+ * Compound file signature of the Excel 97-2003 workbooks
+ */
+var xlsSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+/**
+ * BIFF8 record types
+ */
+const (
+	xlsFormula     = 0x0006
+	xlsEOF         = 0x000A
+	xlsExternSheet = 0x0017
+	xlsDateMode    = 0x0022
+	xlsFilePass    = 0x002F
+	xlsContinue    = 0x003C
+	xlsBoundSheet  = 0x0085
+	xlsMulRk       = 0x00BD
+	xlsRString     = 0x00D6
+	xlsXF          = 0x00E0
+	xlsSST         = 0x00FC
+	xlsLabelSST    = 0x00FD
+	xlsNumber      = 0x0203
+	xlsLabel       = 0x0204
+	xlsBoolErr     = 0x0205
+	xlsString      = 0x0207
+	xlsArray       = 0x0221
+	xlsRk          = 0x027E
+	xlsFormat      = 0x041E
+	xlsShrFmla     = 0x04BC
+	xlsBOF         = 0x0809
+)
+
+/**
+ * First number format id of the workbook custom formats
+ */
+const xlsCustomFormat = 164
+
+/**
+ * Days between the 1900 and the 1904 date systems, the serials of the 1904
+ * workbooks are moved to the 1900 system used by the converted workbook
+ */
+const xls1904Offset = 1462
+
+/**
+ * Formula written when the BIFF tokens can not be converted, the cached
+ * result is still read and the evaluation reports the formula as invalid
+ */
+const xlsUnsupportedFormula = "UNSUPPORTED()"
+
+var xlsErrors = map[byte]string{
+	0x00: "#NULL!",
+	0x07: "#DIV/0!",
+	0x0F: "#VALUE!",
+	0x17: "#REF!",
+	0x1D: "#NAME?",
+	0x24: "#NUM!",
+	0x2A: "#N/A",
+}
+
+/**
+ * Record of the workbook stream, the data of the CONTINUE records that follow
+ * it are kept apart since the strings restart on every one of them
+ */
+type xlsRecord struct {
+	Type   uint16
+	Chunks [][]byte
+}
+
+func (r xlsRecord) data() []byte {
+	return r.Chunks[0]
+}
+
+/**
+ * Cell of a BIFF8 worksheet, Value is a float64, bool or string
+ */
+type xlsCell struct {
+	Row     int
+	Col     int
+	Value   interface{}
+	Format  int
+	Formula string
+	tokens  []byte
+}
+
+type xlsSheet struct {
+	Name   string
+	Offset int
+	Type   byte
+	Cells  []*xlsCell
+}
+
+type xlsWorkbook struct {
+	Sheets   []*xlsSheet
+	Strings  []string
+	Formats  map[int]string
+	XFs      []int
+	Externs  []int
+	Date1904 bool
+}
+
+/**
+ * Check if the file starts with the compound file signature of the Excel
+ * 97-2003 workbooks
+ */
+func isXlsFile(filePath string) bool {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	header := make([]byte, len(xlsSignature))
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false
+	}
+	return bytes.Equal(header, xlsSignature)
+}
+
+/**
+ * Open an Excel 97-2003 workbook as an in memory Excel workbook, the cells
+ * keep their number formats so the values are displayed as on xlsx files
+ */
+func openXlsFile(filePath string) (*excelize.File, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	doc, err := mscfb.New(file)
+	if err != nil {
+		return nil, ErrXlsInvalid
+	}
+	for _, entry := range doc.File {
+		if entry.Name != "Workbook" || len(entry.Path) > 0 {
+			continue
+		}
+		stream, err := io.ReadAll(entry)
+		if err != nil {
+			return nil, ErrXlsInvalid
+		}
+		wb, err := readXlsWorkbook(stream)
+		if err != nil {
+			return nil, err
+		}
+		return xlsToExcel(wb)
+	}
+	return nil, ErrXlsInvalid
+}
+
+/**
+ * Return the record at the stream offset and the offset of the next one
+ */
+func readXlsRecord(stream []byte, offset int) (xlsRecord, int, error) {
+	record := xlsRecord{}
+	for {
+		if offset+4 > len(stream) {
+			if len(record.Chunks) > 0 {
+				return record, offset, nil
+			}
+			return record, offset, ErrXlsInvalid
+		}
+		recType := binary.LittleEndian.Uint16(stream[offset:])
+		size := int(binary.LittleEndian.Uint16(stream[offset+2:]))
+		if len(record.Chunks) > 0 && recType != xlsContinue {
+			return record, offset, nil
+		}
+		if offset+4+size > len(stream) {
+			return record, offset, ErrXlsInvalid
+		}
+		if len(record.Chunks) == 0 {
+			record.Type = recType
+		}
+		record.Chunks = append(record.Chunks, stream[offset+4:offset+4+size])
+		offset += 4 + size
+	}
+}
+
+/**
+ * Decode the workbook globals and worksheets of the BIFF8 stream
+ */
+func readXlsWorkbook(stream []byte) (*xlsWorkbook, error) {
+	wb := &xlsWorkbook{Formats: map[int]string{}}
+
+	record, offset, err := readXlsRecord(stream, 0)
+	if err != nil || record.Type != xlsBOF || len(record.data()) < 4 || binary.LittleEndian.Uint16(record.data()) != 0x0600 {
+		return nil, ErrXlsInvalid
+	}
+	for record.Type != xlsEOF {
+		if record, offset, err = readXlsRecord(stream, offset); err != nil {
+			return nil, err
+		}
+		data := record.data()
+		switch record.Type {
+		case xlsFilePass:
+			return nil, ErrXlsEncrypted
+		case xlsDateMode:
+			wb.Date1904 = len(data) >= 2 && binary.LittleEndian.Uint16(data) == 1
+		case xlsFormat:
+			if len(data) >= 2 {
+				r := &xlsReader{chunks: record.Chunks, pos: 2}
+				wb.Formats[int(binary.LittleEndian.Uint16(data))] = r.unicodeString(int(r.uint16()))
+			}
+		case xlsXF:
+			if len(data) >= 4 {
+				wb.XFs = append(wb.XFs, int(binary.LittleEndian.Uint16(data[2:])))
+			}
+		case xlsBoundSheet:
+			if len(data) >= 8 {
+				r := &xlsReader{chunks: record.Chunks, pos: 6}
+				sheet := &xlsSheet{Offset: int(binary.LittleEndian.Uint32(data)), Type: data[5]}
+				sheet.Name = r.unicodeString(int(r.byte()))
+				wb.Sheets = append(wb.Sheets, sheet)
+			}
+		case xlsExternSheet:
+			r := &xlsReader{chunks: record.Chunks}
+			for i, count := 0, int(r.uint16()); i < count && !r.eof(); i++ {
+				r.skip(2)
+				first := int(int16(r.uint16()))
+				r.skip(2)
+				wb.Externs = append(wb.Externs, first)
+			}
+		case xlsSST:
+			wb.Strings = readXlsStrings(record)
+		}
+	}
+
+	for _, sheet := range wb.Sheets {
+		if sheet.Type != 0 {
+			continue
+		}
+		if err := wb.readSheet(stream, sheet); err != nil {
+			return nil, err
+		}
+	}
+	return wb, nil
+}
+
+/**
+ * Check if the number format shows a date, the time only formats are left
+ * out since their serials have no days to move between the date systems
+ */
+func (wb *xlsWorkbook) isDateFormat(format int) bool {
+	if format < xlsCustomFormat {
+		return (format >= 14 && format <= 17) || format == 22
+	}
+	code := strings.ToLower(wb.Formats[format])
+	tokens := []byte{}
+	for i, quoted, section := 0, false, false; i < len(code); i++ {
+		switch c := code[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[':
+			section = true
+		case c == ']':
+			section = false
+		case section:
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		default:
+			tokens = append(tokens, c)
+		}
+	}
+	s := string(tokens)
+	return strings.ContainsAny(s, "dy") || (strings.Contains(s, "m") && !strings.ContainsAny(s, "hs"))
+}
+
+/**
+ * Return the shared strings table
+ */
+func readXlsStrings(record xlsRecord) []string {
+	r := &xlsReader{chunks: record.Chunks, pos: 8}
+	count := 0
+	if len(record.data()) >= 8 {
+		count = int(binary.LittleEndian.Uint32(record.data()[4:]))
+	}
+	strs := []string{}
+	for i := 0; i < count && !r.eof(); i++ {
+		strs = append(strs, r.richString())
+	}
+	return strs
+}
+
+/**
+ * Read the cells of a worksheet substream, the formulas are converted once
+ * the shared formulas of the sheet are known
+ */
+func (wb *xlsWorkbook) readSheet(stream []byte, sheet *xlsSheet) error {
+	record, offset, err := readXlsRecord(stream, sheet.Offset)
+	if err != nil || record.Type != xlsBOF {
+		return ErrXlsInvalid
+	}
+
+	shared := map[[2]int][]byte{}
+	var pending *xlsCell
+	depth := 1
+	for depth > 0 {
+		if record, offset, err = readXlsRecord(stream, offset); err != nil {
+			return err
+		}
+		data := record.data()
+		switch record.Type {
+		case xlsBOF:
+			depth++
+			continue
+		case xlsEOF:
+			depth--
+			continue
+		}
+		if depth > 1 {
+			continue
+		}
+
+		cell := func(value interface{}) *xlsCell {
+			c := &xlsCell{
+				Row:   int(binary.LittleEndian.Uint16(data)),
+				Col:   int(binary.LittleEndian.Uint16(data[2:])),
+				Value: value,
+			}
+			c.Format = wb.format(int(binary.LittleEndian.Uint16(data[4:])))
+			sheet.Cells = append(sheet.Cells, c)
+			return c
+		}
+
+		switch record.Type {
+		case xlsLabelSST:
+			if len(data) >= 10 {
+				if i := int(binary.LittleEndian.Uint32(data[6:])); i < len(wb.Strings) {
+					cell(wb.Strings[i])
+				}
+			}
+		case xlsLabel, xlsRString:
+			if len(data) >= 8 {
+				r := &xlsReader{chunks: record.Chunks, pos: 6}
+				cell(r.unicodeString(int(r.uint16())))
+			}
+		case xlsNumber:
+			if len(data) >= 14 {
+				cell(math.Float64frombits(binary.LittleEndian.Uint64(data[6:])))
+			}
+		case xlsRk:
+			if len(data) >= 10 {
+				cell(xlsRkValue(binary.LittleEndian.Uint32(data[6:])))
+			}
+		case xlsMulRk:
+			if len(data) < 6 {
+				continue
+			}
+			row := int(binary.LittleEndian.Uint16(data))
+			col := int(binary.LittleEndian.Uint16(data[2:]))
+			for i := 4; i+6 <= len(data)-2; i += 6 {
+				sheet.Cells = append(sheet.Cells, &xlsCell{
+					Row:    row,
+					Col:    col,
+					Value:  xlsRkValue(binary.LittleEndian.Uint32(data[i+2:])),
+					Format: wb.format(int(binary.LittleEndian.Uint16(data[i:]))),
+				})
+				col++
+			}
+		case xlsBoolErr:
+			if len(data) >= 8 {
+				if data[7] == 0 {
+					cell(data[6] != 0)
+				} else {
+					cell(xlsErrors[data[6]])
+				}
+			}
+		case xlsFormula:
+			if len(data) < 22 {
+				continue
+			}
+			var value interface{} = math.Float64frombits(binary.LittleEndian.Uint64(data[6:]))
+			if data[12] == 0xFF && data[13] == 0xFF {
+				switch data[6] {
+				case 1:
+					value = data[8] != 0
+				case 2:
+					value = xlsErrors[data[8]]
+				default:
+					value = ""
+				}
+			}
+			c := cell(value)
+			size := int(binary.LittleEndian.Uint16(data[20:]))
+			if 22+size <= len(data) {
+				c.tokens = data[22 : 22+size]
+			}
+			pending = nil
+			if data[12] == 0xFF && data[13] == 0xFF && data[6] == 0 {
+				pending = c
+			}
+		case xlsString:
+			if pending != nil && len(data) >= 3 {
+				r := &xlsReader{chunks: record.Chunks}
+				pending.Value = r.unicodeString(int(r.uint16()))
+			}
+			pending = nil
+		case xlsShrFmla, xlsArray:
+			start := 8
+			if record.Type == xlsArray {
+				start = 12
+			}
+			if len(data) >= start+2 {
+				size := int(binary.LittleEndian.Uint16(data[start:]))
+				if start+2+size <= len(data) {
+					key := [2]int{int(binary.LittleEndian.Uint16(data)), int(data[4])}
+					shared[key] = data[start+2 : start+2+size]
+				}
+			}
+		}
+	}
+
+	for _, c := range sheet.Cells {
+		if c.tokens == nil {
+			continue
+		}
+		tokens := c.tokens
+		if len(tokens) == 5 && tokens[0] == 0x01 {
+			tokens = shared[[2]int{int(binary.LittleEndian.Uint16(tokens[1:])), int(binary.LittleEndian.Uint16(tokens[3:]))}]
+		}
+		formula, ok := wb.formula(tokens, c.Row, c.Col)
+		if !ok {
+			formula = xlsUnsupportedFormula
+		}
+		c.Formula = formula
+	}
+	return nil
+}
+
+/**
+ * Return the number format id of a cell format index
+ */
+func (wb *xlsWorkbook) format(xf int) int {
+	if xf < len(wb.XFs) {
+		return wb.XFs[xf]
+	}
+	return 0
+}
+
+/**
+ * Decode a RK number, an integer or the high part of a float, optionally
+ * multiplied by 100
+ */
+func xlsRkValue(rk uint32) float64 {
+	var v float64
+	if rk&0x02 != 0 {
+		v = float64(int32(rk) >> 2)
+	} else {
+		v = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		v /= 100
+	}
+	return v
+}
+
+/**
+ * Reader of record data split on CONTINUE records
+ */
+type xlsReader struct {
+	chunks [][]byte
+	chunk  int
+	pos    int
+}
+
+func (r *xlsReader) eof() bool {
+	for r.chunk < len(r.chunks) && r.pos >= len(r.chunks[r.chunk]) {
+		r.chunk++
+		r.pos = 0
+	}
+	return r.chunk >= len(r.chunks)
+}
+
+func (r *xlsReader) byte() byte {
+	if r.eof() {
+		return 0
+	}
+	b := r.chunks[r.chunk][r.pos]
+	r.pos++
+	return b
+}
+
+func (r *xlsReader) uint16() uint16 {
+	return uint16(r.byte()) | uint16(r.byte())<<8
+}
+
+func (r *xlsReader) uint32() uint32 {
+	return uint32(r.uint16()) | uint32(r.uint16())<<16
+}
+
+func (r *xlsReader) skip(n int) {
+	for ; n > 0 && !r.eof(); n-- {
+		r.pos++
+	}
+}
+
+/**
+ * Read the characters of a string, a new options byte starts every CONTINUE
+ * record and sets the characters size of the rest of the string
+ */
+func (r *xlsReader) chars(count int, wide bool) string {
+	units := make([]uint16, 0, count)
+	for len(units) < count && r.chunk < len(r.chunks) {
+		if r.pos >= len(r.chunks[r.chunk]) {
+			if r.chunk+1 >= len(r.chunks) {
+				break
+			}
+			r.chunk++
+			r.pos = 0
+			wide = r.byte()&0x01 != 0
+			continue
+		}
+		if wide {
+			units = append(units, r.uint16())
+		} else {
+			units = append(units, uint16(r.byte()))
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+/**
+ * Read a string with its options byte
+ */
+func (r *xlsReader) unicodeString(count int) string {
+	return r.chars(count, r.byte()&0x01 != 0)
+}
+
+/**
+ * Read a shared strings table entry, the formatting runs and phonetic data
+ * are skipped
+ */
+func (r *xlsReader) richString() string {
+	count := int(r.uint16())
+	options := r.byte()
+	runs, ext := 0, 0
+	if options&0x08 != 0 {
+		runs = int(r.uint16())
+	}
+	if options&0x04 != 0 {
+		ext = int(r.uint32())
+	}
+	s := r.chars(count, options&0x01 != 0)
+	r.skip(runs*4 + ext)
+	return s
+}
+
+/**
+ * BIFF8 functions by index and number of arguments, -1 for variable
+ */
+var xlsFunctions = map[int]struct {
+	Name string
+	Args int
+}{
+	0: {"COUNT", -1}, 1: {"IF", -1}, 2: {"ISNA", 1}, 3: {"ISERROR", 1},
+	4: {"SUM", -1}, 5: {"AVERAGE", -1}, 6: {"MIN", -1}, 7: {"MAX", -1},
+	8: {"ROW", -1}, 9: {"COLUMN", -1}, 10: {"NA", 0}, 15: {"SIN", 1},
+	16: {"COS", 1}, 19: {"PI", 0}, 20: {"SQRT", 1}, 21: {"EXP", 1},
+	22: {"LN", 1}, 23: {"LOG10", 1}, 24: {"ABS", 1}, 25: {"INT", 1},
+	26: {"SIGN", 1}, 27: {"ROUND", 2}, 28: {"LOOKUP", -1}, 29: {"INDEX", -1},
+	30: {"REPT", 2}, 31: {"MID", 3}, 32: {"LEN", 1}, 33: {"VALUE", 1},
+	34: {"TRUE", 0}, 35: {"FALSE", 0}, 36: {"AND", -1}, 37: {"OR", -1},
+	38: {"NOT", 1}, 39: {"MOD", 2}, 48: {"TEXT", 2}, 65: {"DATE", 3},
+	66: {"TIME", 3}, 67: {"DAY", 1}, 68: {"MONTH", 1}, 69: {"YEAR", 1},
+	70: {"WEEKDAY", -1}, 71: {"HOUR", 1}, 72: {"MINUTE", 1}, 73: {"SECOND", 1},
+	74: {"NOW", 0}, 100: {"CHOOSE", -1}, 101: {"HLOOKUP", -1}, 102: {"VLOOKUP", -1},
+	111: {"CHAR", 1}, 112: {"LOWER", 1}, 113: {"UPPER", 1}, 114: {"PROPER", 1},
+	115: {"LEFT", -1}, 116: {"RIGHT", -1}, 117: {"EXACT", 2}, 118: {"TRIM", 1},
+	119: {"REPLACE", 4}, 120: {"SUBSTITUTE", -1}, 121: {"CODE", 1}, 124: {"FIND", -1},
+	169: {"COUNTA", -1}, 183: {"PRODUCT", -1}, 197: {"TRUNC", -1}, 212: {"ROUNDUP", 2},
+	213: {"ROUNDDOWN", 2}, 221: {"TODAY", 0}, 336: {"CONCATENATE", -1}, 337: {"POWER", 2},
+	344: {"SUBTOTAL", -1}, 345: {"SUMIF", -1}, 346: {"COUNTIF", -1},
+}
+
+var xlsOperators = map[byte]string{
+	0x03: "+", 0x04: "-", 0x05: "*", 0x06: "/", 0x07: "^", 0x08: "&",
+	0x09: "<", 0x0A: "<=", 0x0B: "=", 0x0C: ">=", 0x0D: ">", 0x0E: "<>",
+	0x0F: " ", 0x10: ",", 0x11: ":",
+}
+
+/**
+ * Return a cell reference, the relative references of the shared formulas
+ * are offsets from the formula cell
+ */
+func xlsCellRef(row uint16, col uint16, relative bool, baseRow int, baseCol int) string {
+	r, c := int(row), int(col&0x3FFF)
+	rowRel, colRel := col&0x8000 != 0, col&0x4000 != 0
+	if relative {
+		if rowRel {
+			r = (baseRow + int(int16(row))) & 0xFFFF
+		}
+		if colRel {
+			c = (baseCol + int(int8(col&0xFF))) & 0xFF
+		}
+	}
+	name, _ := excelize.ColumnNumberToName(c + 1)
+	ref := ""
+	if !colRel {
+		ref = "$"
+	}
+	ref += name
+	if !rowRel {
+		ref += "$"
+	}
+	return ref + strconv.Itoa(r+1)
+}
+
+/**
+ * Return the sheet prefix of a 3D reference
+ */
+func (wb *xlsWorkbook) sheetPrefix(ixti int) (string, bool) {
+	if ixti >= len(wb.Externs) {
+		return "", false
+	}
+	i := wb.Externs[ixti]
+	if i < 0 || i >= len(wb.Sheets) {
+		return "", false
+	}
+	return "'" + strings.ReplaceAll(wb.Sheets[i].Name, "'", "''") + "'!", true
+}
+
+/**
+ * Convert the parsed formula tokens to the formula text, false is returned
+ * for the unsupported tokens
+ */
+func (wb *xlsWorkbook) formula(tokens []byte, row int, col int) (string, bool) {
+	if len(tokens) == 0 {
+		return "", false
+	}
+	stack := []string{}
+	pop := func(n int) ([]string, bool) {
+		if n > len(stack) {
+			return nil, false
+		}
+		items := append([]string{}, stack[len(stack)-n:]...)
+		stack = stack[:len(stack)-n]
+		return items, true
+	}
+	r := &xlsReader{chunks: [][]byte{tokens}}
+
+	for !r.eof() {
+		ptg := r.byte()
+		if ptg >= 0x20 {
+			ptg = ptg&0x1F | 0x20
+		}
+		switch {
+		case xlsOperators[ptg] != "":
+			items, ok := pop(2)
+			if !ok {
+				return "", false
+			}
+			stack = append(stack, items[0]+xlsOperators[ptg]+items[1])
+			continue
+		}
+
+		switch ptg {
+		case 0x12, 0x13:
+			items, ok := pop(1)
+			if !ok {
+				return "", false
+			}
+			sign := "+"
+			if ptg == 0x13 {
+				sign = "-"
+			}
+			stack = append(stack, sign+items[0])
+		case 0x14:
+			items, ok := pop(1)
+			if !ok {
+				return "", false
+			}
+			stack = append(stack, items[0]+"%")
+		case 0x15:
+			items, ok := pop(1)
+			if !ok {
+				return "", false
+			}
+			stack = append(stack, "("+items[0]+")")
+		case 0x16:
+			stack = append(stack, "")
+		case 0x17:
+			count := int(r.byte())
+			s := r.unicodeString(count)
+			stack = append(stack, `"`+strings.ReplaceAll(s, `"`, `""`)+`"`)
+		case 0x19:
+			options := r.byte()
+			data := int(r.uint16())
+			switch {
+			case options&0x04 != 0:
+				r.skip((data + 1) * 2)
+			case options&0x10 != 0:
+				items, ok := pop(1)
+				if !ok {
+					return "", false
+				}
+				stack = append(stack, "SUM("+items[0]+")")
+			}
+		case 0x1C:
+			stack = append(stack, xlsErrors[r.byte()])
+		case 0x1D:
+			if r.byte() != 0 {
+				stack = append(stack, "TRUE")
+			} else {
+				stack = append(stack, "FALSE")
+			}
+		case 0x1E:
+			stack = append(stack, strconv.Itoa(int(r.uint16())))
+		case 0x1F:
+			v := math.Float64frombits(uint64(r.uint32()) | uint64(r.uint32())<<32)
+			stack = append(stack, strconv.FormatFloat(v, 'f', -1, 64))
+		case 0x21, 0x22:
+			args := -1
+			if ptg == 0x22 {
+				args = int(r.byte() & 0x7F)
+			}
+			index := r.uint16()
+			fn, found := xlsFunctions[int(index)]
+			if !found || index&0x8000 != 0 {
+				return "", false
+			}
+			if args < 0 {
+				if args = fn.Args; args < 0 {
+					return "", false
+				}
+			}
+			items, ok := pop(args)
+			if !ok {
+				return "", false
+			}
+			stack = append(stack, fn.Name+"("+strings.Join(items, ",")+")")
+		case 0x24, 0x2C:
+			rw, cl := r.uint16(), r.uint16()
+			stack = append(stack, xlsCellRef(rw, cl, ptg == 0x2C, row, col))
+		case 0x25, 0x2D:
+			rw1, rw2, cl1, cl2 := r.uint16(), r.uint16(), r.uint16(), r.uint16()
+			stack = append(stack, xlsCellRef(rw1, cl1, ptg == 0x2D, row, col)+":"+xlsCellRef(rw2, cl2, ptg == 0x2D, row, col))
+		case 0x26, 0x27, 0x28:
+			r.skip(6)
+		case 0x29:
+			r.skip(2)
+		case 0x2A, 0x2B:
+			r.skip(int(ptg-0x29) * 4)
+			stack = append(stack, "#REF!")
+		case 0x3A:
+			prefix, ok := wb.sheetPrefix(int(r.uint16()))
+			rw, cl := r.uint16(), r.uint16()
+			if !ok {
+				return "", false
+			}
+			stack = append(stack, prefix+xlsCellRef(rw, cl, false, row, col))
+		case 0x3B:
+			prefix, ok := wb.sheetPrefix(int(r.uint16()))
+			rw1, rw2, cl1, cl2 := r.uint16(), r.uint16(), r.uint16(), r.uint16()
+			if !ok {
+				return "", false
+			}
+			stack = append(stack, prefix+xlsCellRef(rw1, cl1, false, row, col)+":"+xlsCellRef(rw2, cl2, false, row, col))
+		case 0x3C, 0x3D:
+			r.skip(2 + int(ptg-0x3B)*4)
+			stack = append(stack, "#REF!")
+		default:
+			return "", false
+		}
+	}
+	if len(stack) != 1 {
+		return "", false
+	}
+	return stack[0], true
+}
+
+/**
+ * Write the worksheets on a new Excel workbook, the numbers keep the cell
+ * number format
+ */
+func xlsToExcel(wb *xlsWorkbook) (*excelize.File, error) {
+	xlsx := excelize.NewFile()
+	styles := map[int]int{}
+	style := func(format int) int {
+		if id, found := styles[format]; found {
+			return id
+		}
+		s := &excelize.Style{NumFmt: format}
+		if custom, found := wb.Formats[format]; found && format >= xlsCustomFormat {
+			s = &excelize.Style{CustomNumFmt: &custom}
+		}
+		id, err := xlsx.NewStyle(s)
+		if err != nil {
+			id = 0
+		}
+		styles[format] = id
+		return id
+	}
+
+	sheets := 0
+	for _, sheet := range wb.Sheets {
+		if sheet.Type != 0 {
+			continue
+		}
+		if sheets == 0 {
+			xlsx.SetSheetName(xlsx.GetSheetName(0), sheet.Name)
+		} else {
+			xlsx.NewSheet(sheet.Name)
+		}
+		sheets++
+
+		for _, cell := range sheet.Cells {
+			axis, err := excelize.CoordinatesToCellName(cell.Col+1, cell.Row+1)
+			if err != nil {
+				return nil, ErrXlsInvalid
+			}
+			switch v := cell.Value.(type) {
+			case float64:
+				if wb.Date1904 && wb.isDateFormat(cell.Format) {
+					v += xls1904Offset
+				}
+				err = xlsx.SetCellFloat(sheet.Name, axis, v, -1, 64)
+				if err == nil && cell.Format != 0 {
+					if id := style(cell.Format); id != 0 {
+						err = xlsx.SetCellStyle(sheet.Name, axis, axis, id)
+					}
+				}
+			case bool:
+				err = xlsx.SetCellBool(sheet.Name, axis, v)
+			case string:
+				if v != "" || cell.Formula != "" {
+					err = xlsx.SetCellStr(sheet.Name, axis, v)
+				}
+			}
+			if err == nil && cell.Formula != "" {
+				err = xlsx.SetCellFormula(sheet.Name, axis, cell.Formula)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if sheets == 0 {
+		return nil, ErrXlsInvalid
+	}
+	return xlsx, nil
+}
@@ -0,0 +1,392 @@
+package Layouts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/xuri/excelize/v2"
+)
+
+/**
+ * BIFF8 stream builder for the test files
+ */
+type testBiff struct {
+	bytes.Buffer
+}
+
+func (b *testBiff) record(recType uint16, parts ...interface{}) {
+	data := &bytes.Buffer{}
+	for _, p := range parts {
+		binary.Write(data, binary.LittleEndian, p)
+	}
+	binary.Write(&b.Buffer, binary.LittleEndian, recType)
+	binary.Write(&b.Buffer, binary.LittleEndian, uint16(data.Len()))
+	b.Write(data.Bytes())
+}
+
+func testBiffString(s string) []byte {
+	data := []byte{0x01}
+	for _, u := range utf16.Encode([]rune(s)) {
+		data = append(data, byte(u), byte(u>>8))
+	}
+	return data
+}
+
+func testBiffCell(row, col, xf int) []uint16 {
+	return []uint16{uint16(row), uint16(col), uint16(xf)}
+}
+
+/**
+ * Write the workbook stream on a minimal compound file
+ */
+func writeTestXlsFile(t *testing.T, stream []byte) string {
+	for len(stream) < 4096 || len(stream)%512 != 0 {
+		stream = append(stream, 0)
+	}
+	sectors := len(stream) / 512
+	le := binary.LittleEndian
+
+	header := make([]byte, 512)
+	copy(header, xlsSignature)
+	le.PutUint16(header[24:], 0x003E)
+	le.PutUint16(header[26:], 0x0003)
+	le.PutUint16(header[28:], 0xFFFE)
+	le.PutUint16(header[30:], 9)
+	le.PutUint16(header[32:], 6)
+	le.PutUint32(header[44:], 1)
+	le.PutUint32(header[48:], 1)
+	le.PutUint32(header[56:], 4096)
+	le.PutUint32(header[60:], 0xFFFFFFFE)
+	le.PutUint32(header[68:], 0xFFFFFFFE)
+	for i := 76; i < 512; i += 4 {
+		le.PutUint32(header[i:], 0xFFFFFFFF)
+	}
+	le.PutUint32(header[76:], 0)
+
+	fat := make([]byte, 512)
+	for i := 0; i < 128; i++ {
+		le.PutUint32(fat[i*4:], 0xFFFFFFFF)
+	}
+	le.PutUint32(fat[0:], 0xFFFFFFFD)
+	le.PutUint32(fat[4:], 0xFFFFFFFE)
+	for i := 0; i < sectors; i++ {
+		next := uint32(i + 3)
+		if i == sectors-1 {
+			next = 0xFFFFFFFE
+		}
+		le.PutUint32(fat[(i+2)*4:], next)
+	}
+
+	dir := make([]byte, 512)
+	entry := func(i int, name string, objType byte, child uint32, start uint32, size uint32) {
+		e := dir[i*128:]
+		units := utf16.Encode([]rune(name))
+		for j, u := range units {
+			le.PutUint16(e[j*2:], u)
+		}
+		le.PutUint16(e[64:], uint16((len(units)+1)*2))
+		e[66] = objType
+		e[67] = 1
+		le.PutUint32(e[68:], 0xFFFFFFFF)
+		le.PutUint32(e[72:], 0xFFFFFFFF)
+		le.PutUint32(e[76:], child)
+		le.PutUint32(e[116:], start)
+		le.PutUint32(e[120:], size)
+	}
+	entry(0, "Root Entry", 5, 1, 0xFFFFFFFE, 0)
+	entry(1, "Workbook", 2, 0xFFFFFFFF, 2, uint32(len(stream)))
+	for i := 2; i < 4; i++ {
+		le.PutUint32(dir[i*128+68:], 0xFFFFFFFF)
+		le.PutUint32(dir[i*128+72:], 0xFFFFFFFF)
+		le.PutUint32(dir[i*128+76:], 0xFFFFFFFF)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "test.xls")
+	content := append(append(append(header, fat...), dir...), stream...)
+	if err := os.WriteFile(fileName, content, 0644); err != nil {
+		t.Fatalf("Unable to create test file: %s", err.Error())
+	}
+	return fileName
+}
+
+/**
+ * Create a BIFF8 workbook with shared strings split on CONTINUE records,
+ * RK, MULRK and formula cells, and its equivalent xlsx workbook. The dates
+ * are written on the 1904 date system when date1904 is set
+ */
+func createTestXlsFiles(t *testing.T, date1904 bool) (string, string) {
+	days := uint32(0)
+	globals := &testBiff{}
+	globals.record(xlsBOF, uint16(0x0600), uint16(0x0005), uint32(0), uint32(0), uint32(0))
+	if date1904 {
+		days = xls1904Offset
+		globals.record(xlsDateMode, uint16(1))
+	}
+	globals.record(xlsFormat, uint16(164), uint16(5), []byte{0}, []byte("0.000"))
+	for _, ifmt := range []uint16{0, 14, 164} {
+		globals.record(xlsXF, uint16(0), ifmt, make([]byte, 16))
+	}
+	sheetPositions := []int{}
+	for _, name := range []string{"Productos", "Hoja 2"} {
+		sheetPositions = append(sheetPositions, globals.Len()+4)
+		globals.record(xlsBoundSheet, uint32(0), []byte{0, 0, byte(len(name))}, testBiffString(name))
+	}
+	globals.record(xlsExternSheet, uint16(1), uint16(0), uint16(0), uint16(0))
+
+	strs := []string{"ID", "Nombre", "Alta", "Total", "Tornillo largo"}
+	sst := []byte{}
+	for _, s := range strs[:4] {
+		sst = append(sst, byte(len(s)), 0, 0)
+		sst = append(sst, []byte(s)...)
+	}
+	sst = append(sst, byte(len(strs[4])), 0, 0)
+	sst = append(sst, []byte(strs[4][:8])...)
+	globals.record(xlsSST, uint32(len(strs)), uint32(len(strs)), sst)
+	globals.record(xlsContinue, testBiffString(strs[4][8:]))
+	globals.record(xlsEOF)
+
+	sheets := [][]byte{}
+	sheet := &testBiff{}
+	sheet.record(xlsBOF, uint16(0x0600), uint16(0x0010), uint32(0), uint32(0))
+	for i := 0; i < 4; i++ {
+		sheet.record(xlsLabelSST, testBiffCell(0, i, 0), uint32(i))
+	}
+	sheet.record(xlsNumber, testBiffCell(1, 0, 0), float64(1))
+	sheet.record(xlsLabelSST, testBiffCell(1, 1, 0), uint32(4))
+	sheet.record(xlsRk, testBiffCell(1, 2, 1), uint32((44713-days)<<2|0x02))
+	sheet.record(xlsFormula, testBiffCell(1, 3, 2), float64(2), uint16(0), uint32(0), uint16(9), []byte{0x44, 1, 0, 0, 0xC0, 0x1E, 2, 0, 0x05})
+	sheet.record(xlsRk, testBiffCell(2, 0, 0), uint32(2<<2|0x02))
+	sheet.record(xlsLabel, testBiffCell(2, 1, 0), uint16(6), testBiffString("Ñandú!"))
+	sheet.record(xlsMulRk, uint16(2), uint16(2), uint16(1), uint32((44714-days)<<2|0x02), uint16(2), uint32(uint32(math.Float64bits(7.5)>>32)), uint16(3))
+	sheet.record(xlsFormula, testBiffCell(3, 3, 2), float64(4), uint16(0), uint32(0), uint16(5), []byte{0x01, 3, 0, 3, 0})
+	sheet.record(xlsShrFmla, uint16(3), uint16(3), []byte{3, 3, 0, 1}, uint16(9), []byte{0x4C, 0, 0, 0xFD, 0xC0, 0x1E, 2, 0, 0x05})
+	sheet.record(xlsRk, testBiffCell(3, 0, 0), uint32(2<<2|0x02))
+	sheet.record(xlsEOF)
+	sheets = append(sheets, sheet.Bytes())
+
+	sheet = &testBiff{}
+	sheet.record(xlsBOF, uint16(0x0600), uint16(0x0010), uint32(0), uint32(0))
+	sheet.record(xlsFormula, testBiffCell(0, 0, 0), []byte{0, 0, 0, 0, 0, 0, 0xFF, 0xFF}, uint16(0), uint32(0), uint16(7), []byte{0x5A, 0, 0, 0, 0, 0, 0})
+	sheet.record(xlsString, uint16(2), testBiffString("ID"))
+	sheet.record(xlsBoolErr, testBiffCell(0, 1, 0), []byte{1, 0})
+	sheet.record(xlsBoolErr, testBiffCell(0, 2, 0), []byte{0x2A, 1})
+	sheet.record(xlsEOF)
+	sheets = append(sheets, sheet.Bytes())
+
+	stream := globals.Bytes()
+	offset := len(stream)
+	for i, s := range sheets {
+		binary.LittleEndian.PutUint32(stream[sheetPositions[i]:], uint32(offset))
+		offset += len(s)
+	}
+	for _, s := range sheets {
+		stream = append(stream, s...)
+	}
+	xlsName := writeTestXlsFile(t, stream)
+
+	xlsx := excelize.NewFile()
+	xlsx.SetSheetName(xlsx.GetSheetName(0), "Productos")
+	xlsx.NewSheet("Hoja 2")
+	xlsx.SetSheetRow("Productos", "A1", &[]interface{}{"ID", "Nombre", "Alta", "Total"})
+	xlsx.SetSheetRow("Productos", "A2", &[]interface{}{1, "Tornillo largo", 44713, 2})
+	xlsx.SetSheetRow("Productos", "A3", &[]interface{}{2, "Ñandú!", 44714, 7.5})
+	xlsx.SetSheetRow("Productos", "A4", &[]interface{}{2, nil, nil, 4})
+	xlsx.SetCellFormula("Productos", "D2", "A2*2")
+	xlsx.SetCellFormula("Productos", "D4", "A4*2")
+	date, _ := xlsx.NewStyle(&excelize.Style{NumFmt: 14})
+	xlsx.SetCellStyle("Productos", "C2", "C3", date)
+	custom := "0.000"
+	decimals, _ := xlsx.NewStyle(&excelize.Style{CustomNumFmt: &custom})
+	xlsx.SetCellStyle("Productos", "D2", "D4", decimals)
+	xlsx.SetSheetRow("Hoja 2", "A1", &[]interface{}{"ID", true, "#N/A"})
+	xlsx.SetCellFormula("Hoja 2", "A1", "'Productos'!A1")
+	xlsxName := filepath.Join(t.TempDir(), "test.xlsx")
+	if err := xlsx.SaveAs(xlsxName); err != nil {
+		t.Fatalf("Unable to save test file: %s", err.Error())
+	}
+	return xlsName, xlsxName
+}
+
+func TestXlsSameRowsAsXlsx(t *testing.T) {
+	xlsName, xlsxName := createTestXlsFiles(t, false)
+	if !isXlsFile(xlsName) || isXlsFile(xlsxName) {
+		t.Fatalf("Expected only the xls file to be detected")
+	}
+
+	xls, err := openXlsFile(xlsName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	xlsx, err := excelize.OpenFile(xlsxName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(xls.GetSheetList(), xlsx.GetSheetList()) {
+		t.Fatalf("Expected sheets %v, Recived: %v", xlsx.GetSheetList(), xls.GetSheetList())
+	}
+
+	for i, raw := range []bool{false, true} {
+		for _, sheet := range xlsx.GetSheetList() {
+			expected, _ := xlsx.GetRows(sheet, excelize.Options{RawCellValue: raw})
+			result, _ := xls.GetRows(sheet, excelize.Options{RawCellValue: raw})
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("Test %d: Expected %q, Recived: %q", i, expected, result)
+			}
+		}
+	}
+
+	for _, axis := range []string{"D2", "D4"} {
+		expected, _ := xlsx.GetCellFormula("Productos", axis)
+		if result, _ := xls.GetCellFormula("Productos", axis); result != expected {
+			t.Errorf("Expected formula %s, Recived: %s", expected, result)
+		}
+	}
+	if result, _ := xls.GetCellFormula("Hoja 2", "A1"); result != "'Productos'!$A$1" {
+		t.Errorf("Expected formula 'Productos'!$A$1, Recived: %s", result)
+	}
+}
+
+func TestXlsDate1904(t *testing.T) {
+	xlsName, xlsxName := createTestXlsFiles(t, true)
+	xls, err := openXlsFile(xlsName)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	xlsx, _ := excelize.OpenFile(xlsxName)
+	for i, raw := range []bool{false, true} {
+		expected, _ := xlsx.GetRows("Productos", excelize.Options{RawCellValue: raw})
+		result, _ := xls.GetRows("Productos", excelize.Options{RawCellValue: raw})
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("Test %d: Expected %q, Recived: %q", i, expected, result)
+		}
+	}
+
+	wb := &xlsWorkbook{Formats: map[int]string{
+		164: "dd/mm/yyyy",
+		165: "hh:mm:ss",
+		166: `0.00 "días"`,
+		167: "[$-409]mmm-yy",
+		168: "mm:ss",
+		169: "0.000",
+	}}
+	tests := map[int]bool{0: false, 2: false, 14: true, 17: true, 20: false, 22: true, 164: true, 165: false, 166: false, 167: true, 168: false, 169: false}
+	for format, expected := range tests {
+		if result := wb.isDateFormat(format); result != expected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", format, expected, result)
+		}
+	}
+}
+
+type testXlsRow struct {
+	Row
+	ID    int64     `excelLayout:"header:ID,required"`
+	Name  string    `excelLayout:"header:Nombre"`
+	Date  time.Time `excelLayout:"header:Alta"`
+	Total float64   `excelLayout:"header:Total"`
+}
+
+func TestXlsFileRead(t *testing.T) {
+	xlsName, _ := createTestXlsFiles(t, false)
+
+	l := ExcelLayout{}
+	l.RawValues()
+	l.Formulas(FormulaEvaluate)
+	if err := l.ReadFile(testXlsRow{}, xlsName); err != nil {
+		t.Fatalf("Unexpected error: %v %v", err, l.GetErrors())
+	}
+	expected := []interface{}{
+		&testXlsRow{Row: Row{Index: 2}, ID: 1, Name: "Tornillo largo", Date: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC), Total: 2},
+		&testXlsRow{Row: Row{Index: 3}, ID: 2, Name: "Ñandú!", Date: time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC), Total: 7.5},
+		&testXlsRow{Row: Row{Index: 4}, ID: 2, Total: 4},
+	}
+	if rows := l.GetRows(); !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Test 0: Expected %v, Recived: %v", expected, rows)
+	}
+
+	l = ExcelLayout{}
+	l.RawValues()
+	l.Formulas(FormulaReject)
+	if err := l.ReadFile(testXlsRow{}, xlsName); err != ErrValidationFail {
+		t.Fatalf("Test 1: Expected %v, Recived: %v", ErrValidationFail, err)
+	}
+	expectedErrors := []Error{
+		{RowIndex: 2, Column: "D", Error: ErrFormulaRejected},
+		{RowIndex: 4, Column: "D", Error: ErrFormulaRejected},
+	}
+	if errs := l.GetErrors(); !reflect.DeepEqual(errs, expectedErrors) {
+		t.Errorf("Test 1: Expected %v, Recived: %v", expectedErrors, errs)
+	}
+}
+
+func TestXlsInvalidFile(t *testing.T) {
+	fileName := writeTestXlsFile(t, []byte{0x09, 0x08, 0x04, 0x00, 0x00, 0x05, 0x05, 0x00})
+
+	l := ExcelLayout{}
+	if err := l.ReadFile(testXlsRow{}, fileName); err != ErrXlsInvalid {
+		t.Errorf("Test 0: Expected %v, Recived: %v", ErrXlsInvalid, err)
+	}
+
+	biff := &testBiff{}
+	biff.record(xlsBOF, uint16(0x0600), uint16(0x0005), uint32(0), uint32(0))
+	biff.record(xlsFilePass, uint16(1))
+	biff.record(xlsEOF)
+	fileName = writeTestXlsFile(t, biff.Bytes())
+	if err := l.ReadFile(testXlsRow{}, fileName); err != ErrXlsEncrypted {
+		t.Errorf("Test 1: Expected %v, Recived: %v", ErrXlsEncrypted, err)
+	}
+}
+
+func TestXlsRkValue(t *testing.T) {
+
+	tests := []struct {
+		input    uint32
+		expected float64
+	}{
+		{uint32(44713<<2 | 0x02), 44713},
+		{uint32(0xFFFFFFEE), -5},
+		{uint32(1234<<2 | 0x03), 12.34},
+		{uint32(math.Float64bits(7.5) >> 32), 7.5},
+	}
+
+	for i, test := range tests {
+		if result := xlsRkValue(test.input); result != test.expected {
+			t.Errorf("Test %d: Expected %v, Recived: %v", i, test.expected, result)
+		}
+	}
+}
+
+func TestXlsFormula(t *testing.T) {
+	wb := &xlsWorkbook{
+		Sheets:  []*xlsSheet{{Name: "Hoja1"}, {Name: "O'Brien"}},
+		Externs: []int{1},
+	}
+
+	tests := []struct {
+		input    []byte
+		expected string
+		ok       bool
+	}{
+		{[]byte{0x1E, 1, 0, 0x1E, 2, 0, 0x03}, "1+2", true},
+		{[]byte{0x25, 0, 0, 9, 0, 0, 0xC0, 1, 0xC0, 0x19, 0x10, 0, 0}, "SUM(A1:B10)", true},
+		{[]byte{0x17, 3, 0, 'a', '"', 'b', 0x17, 1, 0, 'c', 0x08}, `"a""b"&"c"`, true},
+		{[]byte{0x24, 0, 0, 0, 0, 0x1F, 0, 0, 0, 0, 0, 0, 0xF8, 0x3F, 0x06, 0x15}, "($A$1/1.5)", true},
+		{[]byte{0x3A, 0, 0, 4, 0, 2, 0xC0, 0x21, 27, 0}, "", false},
+		{[]byte{0x3A, 0, 0, 4, 0, 2, 0xC0, 0x1E, 2, 0, 0x41, 27, 0}, "ROUND('O''Brien'!C5,2)", true},
+		{[]byte{0x1D, 1, 0x13, 0x42, 1, 0xFF, 0x00}, "", false},
+		{[]byte{0x23, 1, 0, 0, 0}, "", false},
+	}
+
+	for i, test := range tests {
+		result, ok := wb.formula(test.input, 0, 0)
+		if ok != test.ok || (ok && result != test.expected) {
+			t.Errorf("Test %d: Expected %s %v, Recived: %s %v", i, test.expected, test.ok, result, ok)
+		}
+	}
+}